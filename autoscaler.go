@@ -0,0 +1,355 @@
+package runpod
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// ScaleAction is the change an autoscaler makes to an endpoint's workers
+type ScaleAction string
+
+const (
+	ScaleActionNone ScaleAction = "none"
+	ScaleActionUp   ScaleAction = "up"
+	ScaleActionDown ScaleAction = "down"
+)
+
+// AutoscalePolicy decides how many workers an endpoint needs from its health.
+// The desired worker count is the active workers plus enough to keep at most
+// TargetQueuePerWorker queued jobs per worker, less the idle workers that can
+// take queued jobs already, clamped to MinWorkers and MaxWorkers. It becomes the endpoint's WorkersMin, and WorkersMax is set
+// Headroom workers above it.
+type AutoscalePolicy struct {
+	// TargetQueuePerWorker is how many queued jobs each worker may have waiting. Defaults to 1.
+	TargetQueuePerWorker float64
+
+	// MinWorkers and MaxWorkers bound the desired worker count. MaxWorkers is required.
+	MinWorkers int
+	MaxWorkers int
+
+	// Headroom lets RunPod's own scaler add this many workers above WorkersMin
+	// to absorb bursts between checks. WorkersMax never exceeds MaxWorkers.
+	Headroom int
+
+	// ScaleUpCooldown is the minimum time from the last change to a scale-up. Defaults to 1 minute.
+	ScaleUpCooldown time.Duration
+
+	// ScaleDownCooldown is the minimum time from the last change to a scale-down. Defaults to 5 minutes.
+	ScaleDownCooldown time.Duration
+}
+
+// ScaleState is an endpoint's current worker limits and when they last changed
+type ScaleState struct {
+	WorkersMin int
+	WorkersMax int
+	LastScaled time.Time
+}
+
+// ScaleDecision is what a policy decided for one health reading
+type ScaleDecision struct {
+	Action ScaleAction
+
+	// Desired is the worker count the policy wants, before cooldowns
+	Desired int
+
+	// WorkersMin and WorkersMax are the limits to apply, unchanged for ScaleActionNone
+	WorkersMin int
+	WorkersMax int
+
+	Reason string
+}
+
+// withDefaults validates the policy and fills in defaults
+func (p AutoscalePolicy) withDefaults() (AutoscalePolicy, error) {
+	if p.MaxWorkers <= 0 {
+		return p, NewValidationErrorWithValue("MaxWorkers", "must be positive", p.MaxWorkers)
+	}
+	if p.MinWorkers < 0 || p.MinWorkers > p.MaxWorkers {
+		return p, NewValidationErrorWithValue("MinWorkers", "must be between 0 and MaxWorkers", p.MinWorkers)
+	}
+	if p.Headroom < 0 || p.TargetQueuePerWorker < 0 || p.ScaleUpCooldown < 0 || p.ScaleDownCooldown < 0 {
+		return p, NewValidationError("policy", "Headroom, TargetQueuePerWorker and cooldowns cannot be negative")
+	}
+
+	if p.TargetQueuePerWorker == 0 {
+		p.TargetQueuePerWorker = 1
+	}
+	if p.ScaleUpCooldown == 0 {
+		p.ScaleUpCooldown = time.Minute
+	}
+	if p.ScaleDownCooldown == 0 {
+		p.ScaleDownCooldown = 5 * time.Minute
+	}
+	return p, nil
+}
+
+// Desired returns the worker count the policy wants for a health reading
+func (p AutoscalePolicy) Desired(health *EndpointHealth) int {
+	target := p.TargetQueuePerWorker
+	if target <= 0 {
+		target = 1
+	}
+
+	// Idle workers pick up queued jobs before new workers could start
+	needed := int(math.Ceil(float64(health.JobsInQueue)/target)) - health.WorkersIdle
+	desired := health.WorkersActive + max(needed, 0)
+	return min(max(desired, p.MinWorkers), p.MaxWorkers)
+}
+
+// Decide returns the scaling decision for a health reading taken at now. It
+// uses the policy as given; NewAutoscaler and Simulate fill in the defaults.
+func (p AutoscalePolicy) Decide(state ScaleState, health *EndpointHealth, now time.Time) ScaleDecision {
+	desired := p.Desired(health)
+	decision := ScaleDecision{
+		Action:     ScaleActionNone,
+		Desired:    desired,
+		WorkersMin: state.WorkersMin,
+		WorkersMax: state.WorkersMax,
+	}
+
+	workersMin := desired
+	workersMax := min(desired+p.Headroom, p.MaxWorkers)
+	if workersMin == state.WorkersMin && workersMax == state.WorkersMax {
+		decision.Reason = fmt.Sprintf("at target of %d workers", desired)
+		return decision
+	}
+
+	action, cooldown := ScaleActionDown, p.ScaleDownCooldown
+	if workersMin > state.WorkersMin || (workersMin == state.WorkersMin && workersMax > state.WorkersMax) {
+		action, cooldown = ScaleActionUp, p.ScaleUpCooldown
+	}
+
+	if wait := cooldown - now.Sub(state.LastScaled); !state.LastScaled.IsZero() && wait > 0 {
+		decision.Reason = fmt.Sprintf("scale %s to %d workers waits for cooldown (%s left)", action, desired, wait.Round(time.Second))
+		return decision
+	}
+
+	decision.Action = action
+	decision.WorkersMin = workersMin
+	decision.WorkersMax = workersMax
+	decision.Reason = fmt.Sprintf("%d queued, %d active: %d workers wanted", health.JobsInQueue, health.WorkersActive, desired)
+	return decision
+}
+
+// AutoscalerConfig configures an Autoscaler
+type AutoscalerConfig struct {
+	// EndpointID is the serverless endpoint to scale. Required.
+	EndpointID string
+
+	// Policy decides the endpoint's worker limits
+	Policy AutoscalePolicy
+
+	// DryRun records decisions without updating the endpoint
+	DryRun bool
+
+	// Interval is how often Run checks the endpoint. Defaults to 30 seconds.
+	Interval time.Duration
+
+	// AuditLog receives one JSON ScaleEvent per check. The log doubles as a
+	// recording of health samples for ReadHealthSamples and Simulate.
+	AuditLog io.Writer
+}
+
+// ScaleEvent records one autoscaler check and the change made, or planned in
+// dry-run mode
+type ScaleEvent struct {
+	Time       time.Time      `json:"time"`
+	EndpointID string         `json:"endpointId,omitempty"`
+	Health     EndpointHealth `json:"health"`
+	Action     ScaleAction    `json:"action"`
+	Desired    int            `json:"desired"`
+	FromMin    int            `json:"fromMin"`
+	FromMax    int            `json:"fromMax"`
+	WorkersMin int            `json:"workersMin"`
+	WorkersMax int            `json:"workersMax"`
+	Reason     string         `json:"reason"`
+	DryRun     bool           `json:"dryRun"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// Autoscaler adjusts an endpoint's WorkersMin and WorkersMax from its queue
+// depth and worker counts, as reported by GetHealth
+type Autoscaler struct {
+	client *Client
+	cfg    AutoscalerConfig
+
+	mu         sync.Mutex
+	lastScaled time.Time
+
+	auditMu sync.Mutex
+}
+
+// NewAutoscaler validates cfg and creates an autoscaler
+func (c *Client) NewAutoscaler(cfg AutoscalerConfig) (*Autoscaler, error) {
+	if err := c.validateRequired("EndpointID", cfg.EndpointID); err != nil {
+		return nil, err
+	}
+
+	policy, err := cfg.Policy.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Policy = policy
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = 30 * time.Second
+	}
+
+	return &Autoscaler{client: c, cfg: cfg}, nil
+}
+
+// RunOnce reads the endpoint's health and applies the policy's decision. A
+// failed update is reported in the returned event rather than as an error.
+func (a *Autoscaler) RunOnce(ctx context.Context) (*ScaleEvent, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	health, err := a.client.GetHealth(ctx, a.cfg.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := a.client.GetEndpoint(ctx, a.cfg.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	state := ScaleState{WorkersMin: endpoint.WorkersMin, WorkersMax: endpoint.WorkersMax, LastScaled: a.lastScaled}
+	decision := a.cfg.Policy.Decide(state, health, now)
+	event := newScaleEvent(a.cfg.EndpointID, now, health, state, decision, a.cfg.DryRun)
+
+	if decision.Action != ScaleActionNone && !a.cfg.DryRun {
+		_, err := a.client.UpdateEndpoint(ctx, a.cfg.EndpointID, &UpdateEndpointRequest{
+			WorkersMin: decision.WorkersMin,
+			WorkersMax: decision.WorkersMax,
+			SetWorkers: true,
+		})
+		if err != nil {
+			event.Error = err.Error()
+		} else {
+			a.lastScaled = now
+		}
+	}
+
+	if a.client.IsDebugEnabled() {
+		a.client.GetLogger().Printf("[DEBUG] Autoscaler %s endpoint %s: %s", decision.Action, a.cfg.EndpointID, decision.Reason)
+	}
+
+	a.audit(event)
+	return &event, nil
+}
+
+// Run calls RunOnce every Interval until ctx is cancelled
+func (a *Autoscaler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(a.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := a.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			a.client.GetLogger().Printf("[AUTOSCALER] %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// audit appends an event to the audit log
+func (a *Autoscaler) audit(event ScaleEvent) {
+	if a.cfg.AuditLog == nil {
+		return
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	a.auditMu.Lock()
+	defer a.auditMu.Unlock()
+
+	if _, err := a.cfg.AuditLog.Write(append(data, '\n')); err != nil {
+		a.client.GetLogger().Printf("[AUTOSCALER] Failed to write audit log: %v", err)
+	}
+}
+
+// newScaleEvent records a decision made from state
+func newScaleEvent(endpointID string, now time.Time, health *EndpointHealth, state ScaleState, decision ScaleDecision, dryRun bool) ScaleEvent {
+	return ScaleEvent{
+		Time:       now,
+		EndpointID: endpointID,
+		Health:     *health,
+		Action:     decision.Action,
+		Desired:    decision.Desired,
+		FromMin:    state.WorkersMin,
+		FromMax:    state.WorkersMax,
+		WorkersMin: decision.WorkersMin,
+		WorkersMax: decision.WorkersMax,
+		Reason:     decision.Reason,
+		DryRun:     dryRun,
+	}
+}
+
+// HealthSample is an endpoint health reading taken at a point in time
+type HealthSample struct {
+	Time   time.Time      `json:"time"`
+	Health EndpointHealth `json:"health"`
+}
+
+// ReadHealthSamples reads JSON lines of health samples, such as an
+// autoscaler's audit log, skipping blank lines
+func ReadHealthSamples(r io.Reader) ([]HealthSample, error) {
+	var samples []HealthSample
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var sample HealthSample
+		if err := json.Unmarshal(scanner.Bytes(), &sample); err != nil {
+			return nil, fmt.Errorf("failed to parse health sample on line %d: %w", line, err)
+		}
+		samples = append(samples, sample)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read health samples: %w", err)
+	}
+
+	return samples, nil
+}
+
+// Simulate replays recorded health samples through a policy, starting from
+// initial and applying every decision, and returns the event for each sample.
+// The samples are replayed as recorded, so they do not react to the simulated
+// scaling; the result shows when and how far the policy would have scaled.
+func Simulate(policy AutoscalePolicy, initial ScaleState, samples []HealthSample) ([]ScaleEvent, error) {
+	policy, err := policy.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	state := initial
+	events := make([]ScaleEvent, 0, len(samples))
+	for _, sample := range samples {
+		decision := policy.Decide(state, &sample.Health, sample.Time)
+		events = append(events, newScaleEvent("", sample.Time, &sample.Health, state, decision, true))
+
+		if decision.Action != ScaleActionNone {
+			state = ScaleState{WorkersMin: decision.WorkersMin, WorkersMax: decision.WorkersMax, LastScaled: sample.Time}
+		}
+	}
+
+	return events, nil
+}
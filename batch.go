@@ -0,0 +1,339 @@
+package runpod
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// BatchStatusSubmitted marks a result record written right after a job was
+// submitted, before its outcome is known
+const BatchStatusSubmitted = "SUBMITTED"
+
+// BatchOptions configures RunBatchFile
+type BatchOptions struct {
+	// Concurrency is how many jobs may be in flight at once. Defaults to 4.
+	Concurrency int
+
+	// PollInterval is how often job status is checked. Defaults to 2 seconds.
+	PollInterval time.Duration
+
+	// MaxWaitTime bounds how long a single job is waited for. Jobs still running
+	// afterwards stay recorded as submitted and are picked up on resume.
+	// Defaults to 30 minutes.
+	MaxWaitTime time.Duration
+
+	// RetryFailed resubmits lines recorded as failed, cancelled or timed out
+	// instead of skipping them on resume
+	RetryFailed bool
+
+	// OnResult is called for every final result
+	OnResult func(BatchResult)
+}
+
+// BatchResult is one record of a batch results file
+type BatchResult struct {
+	// Line is the 1-based line number of the input
+	Line   int         `json:"line"`
+	JobID  string      `json:"jobId,omitempty"`
+	Status string      `json:"status"`
+	Output interface{} `json:"output,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// BatchReport summarizes a RunBatchFile run
+type BatchReport struct {
+	Total     int `json:"total"`     // non-empty input lines
+	Skipped   int `json:"skipped"`   // lines already finished in the results file
+	Resumed   int `json:"resumed"`   // jobs re-attached to after a previous run stopped
+	Submitted int `json:"submitted"` // jobs submitted during this run
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+	Pending   int `json:"pending"` // jobs still unfinished when the run ended
+}
+
+// RunBatchFile submits each line of a JSONL input file as a job input and
+// appends the outcome to a JSONL results file, keyed by line number and job ID.
+//
+// Every job is recorded as SUBMITTED as soon as RunPod accepts it, so running
+// again with the same results file resumes a crashed or cancelled run: finished
+// lines are skipped and submitted jobs are waited on instead of resubmitted.
+func (c *Client) RunBatchFile(ctx context.Context, endpointID, inputPath, resultsPath string, opts *BatchOptions) (*BatchReport, error) {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return nil, err
+	}
+	if err := c.validateRequired("inputPath", inputPath); err != nil {
+		return nil, err
+	}
+	if err := c.validateRequired("resultsPath", resultsPath); err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &BatchOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	previous, truncated, err := readBatchResults(resultsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := os.Open(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open batch input: %w", err)
+	}
+	defer input.Close()
+
+	output, err := os.OpenFile(resultsPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open batch results: %w", err)
+	}
+	defer output.Close()
+
+	if truncated {
+		// Terminate a record cut short by a crash so new records start on their own line
+		if _, err := output.Write([]byte{'\n'}); err != nil {
+			return nil, fmt.Errorf("failed to write batch results: %w", err)
+		}
+	}
+
+	run := &batchRun{
+		client:     c,
+		endpointID: endpointID,
+		opts:       opts,
+		results:    output,
+		report:     &BatchReport{},
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	reader := bufio.NewReader(input)
+	for line := 1; ; line++ {
+		raw, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			wg.Wait()
+			return run.report, fmt.Errorf("failed to read batch input line %d: %w", line, readErr)
+		}
+
+		if raw = bytes.TrimSpace(raw); len(raw) > 0 {
+			run.report.Total++
+
+			if !run.recordOnly(line, raw, previous[line]) {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+				}
+				if ctx.Err() != nil {
+					break
+				}
+
+				wg.Add(1)
+				go func(line int, raw []byte, prev *BatchResult) {
+					defer wg.Done()
+					defer func() { <-sem }()
+					run.process(ctx, line, raw, prev)
+				}(line, raw, previous[line])
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+	}
+
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return run.report, ctx.Err()
+	}
+	if run.writeErr != nil {
+		return run.report, run.writeErr
+	}
+	return run.report, nil
+}
+
+// batchRun holds the state shared by the goroutines of RunBatchFile
+type batchRun struct {
+	client     *Client
+	endpointID string
+	opts       *BatchOptions
+
+	mu       sync.Mutex
+	results  io.Writer
+	report   *BatchReport
+	writeErr error
+}
+
+// recordOnly handles lines that need no job, returning false if the line must be processed
+func (r *batchRun) recordOnly(line int, raw []byte, prev *BatchResult) bool {
+	if prev != nil && r.client.IsJobTerminal(prev.Status) {
+		if prev.Status == string(JobStatusCompleted) || !r.opts.RetryFailed {
+			r.mu.Lock()
+			r.report.Skipped++
+			r.mu.Unlock()
+			return true
+		}
+	}
+
+	if !json.Valid(raw) {
+		r.finish(BatchResult{Line: line, Status: string(JobStatusFailed), Error: "input is not valid JSON"})
+		return true
+	}
+
+	return false
+}
+
+// process submits a line, or re-attaches to its earlier job, and records the outcome
+func (r *batchRun) process(ctx context.Context, line int, raw []byte, prev *BatchResult) {
+	var jobID string
+
+	if prev != nil && prev.JobID != "" && !r.client.IsJobTerminal(prev.Status) {
+		jobID = prev.JobID
+		r.mu.Lock()
+		r.report.Resumed++
+		r.mu.Unlock()
+	} else {
+		var input interface{}
+		if err := json.Unmarshal(raw, &input); err != nil {
+			r.finish(BatchResult{Line: line, Status: string(JobStatusFailed), Error: err.Error()})
+			return
+		}
+
+		job, err := r.client.RunAsync(ctx, r.endpointID, input)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.finish(BatchResult{Line: line, Status: string(JobStatusFailed), Error: err.Error()})
+			return
+		}
+
+		jobID = job.ID
+		r.mu.Lock()
+		r.report.Submitted++
+		r.writeLocked(BatchResult{Line: line, JobID: jobID, Status: BatchStatusSubmitted})
+		r.mu.Unlock()
+	}
+
+	job, err := r.wait(ctx, jobID)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.client.GetLogger().Printf("[BATCH] Line %d: job %s left pending: %v", line, jobID, err)
+		}
+		r.mu.Lock()
+		r.report.Pending++
+		r.mu.Unlock()
+		return
+	}
+
+	r.finish(BatchResult{Line: line, JobID: jobID, Status: job.Status, Output: job.Output, Error: job.Error})
+}
+
+// wait polls a job until it reaches a terminal status
+func (r *batchRun) wait(ctx context.Context, jobID string) (*Job, error) {
+	pollInterval := r.opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 2 * time.Second
+	}
+	maxWaitTime := r.opts.MaxWaitTime
+	if maxWaitTime <= 0 {
+		maxWaitTime = 30 * time.Minute
+	}
+
+	deadline := time.Now().Add(maxWaitTime)
+	for {
+		job, err := r.client.GetJobStatus(ctx, r.endpointID, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if r.client.IsJobTerminal(job.Status) {
+			return job, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("job %s did not finish within %v", jobID, maxWaitTime)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// finish records a final result and counts it in the report
+func (r *batchRun) finish(result BatchResult) {
+	r.mu.Lock()
+	if result.Status == string(JobStatusCompleted) {
+		r.report.Completed++
+	} else {
+		r.report.Failed++
+	}
+	r.writeLocked(result)
+	r.mu.Unlock()
+
+	if r.opts.OnResult != nil {
+		r.opts.OnResult(result)
+	}
+}
+
+// writeLocked appends a record to the results file. r.mu must be held.
+func (r *batchRun) writeLocked(result BatchResult) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		data, _ = json.Marshal(BatchResult{
+			Line:   result.Line,
+			JobID:  result.JobID,
+			Status: result.Status,
+			Error:  fmt.Sprintf("failed to marshal output: %v", err),
+		})
+	}
+
+	if _, err := r.results.Write(append(data, '\n')); err != nil && r.writeErr == nil {
+		r.writeErr = fmt.Errorf("failed to write batch result: %w", err)
+	}
+}
+
+// readBatchResults loads the latest record for each line of a results file.
+// A missing file yields no records, and a truncated last line is ignored and
+// reported so the caller can terminate it.
+func readBatchResults(path string) (map[int]*BatchResult, bool, error) {
+	results := make(map[int]*BatchResult)
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return results, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open batch results: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		raw, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return nil, false, fmt.Errorf("failed to read batch results: %w", readErr)
+		}
+		if readErr == io.EOF {
+			return results, len(raw) > 0, nil
+		}
+
+		var result BatchResult
+		if raw = bytes.TrimSpace(raw); len(raw) > 0 && json.Unmarshal(raw, &result) == nil && result.Line > 0 {
+			results[result.Line] = &result
+		}
+	}
+}
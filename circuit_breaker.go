@@ -0,0 +1,331 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a circuit breaker circuit
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through and counts consecutive failures
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen fails requests immediately with a *CircuitOpenError
+	CircuitOpen
+
+	// CircuitHalfOpen lets a limited number of probe requests through to test recovery
+	CircuitHalfOpen
+)
+
+// String returns the state's name
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitBreakerOptions configures a CircuitBreaker
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures that opens a circuit. Defaults to 5.
+	FailureThreshold int
+
+	// OpenTimeout is how long a circuit stays open before probing. Defaults to 30 seconds.
+	OpenTimeout time.Duration
+
+	// HalfOpenProbes is how many probe requests may be in flight while half-open. Defaults to 1.
+	HalfOpenProbes int
+
+	// PerEndpoint keeps a separate circuit for each serverless endpoint ID
+	// instead of one per base URL, so one failing endpoint does not block the others
+	PerEndpoint bool
+
+	// OnStateChange is called whenever a circuit changes state. It runs with
+	// the breaker locked, so it must not call the breaker's methods.
+	OnStateChange func(key string, from, to CircuitState)
+}
+
+// CircuitOpenError is returned without sending the request while a circuit is open
+type CircuitOpenError struct {
+	Key        string
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker open for %s: failing fast (retry after %s)", e.Key, e.RetryAfter.Round(time.Millisecond))
+}
+
+// IsCircuitOpenError checks if an error, or any error it wraps, is a CircuitOpenError
+func IsCircuitOpenError(err error) bool {
+	var circuitErr *CircuitOpenError
+	return errors.As(err, &circuitErr)
+}
+
+// CircuitBreaker tracks request failures per base URL, or per serverless
+// endpoint, and fails fast while a circuit is open. A breaker is safe for
+// concurrent use and can be shared by several clients with WithCircuitBreaker.
+//
+// Network errors, timeouts and 5xx responses count as failures. Other responses,
+// including 4xx errors, show the API is up and count as successes. Errors
+// raised before a request is sent, such as a failing CredentialsProvider,
+// are ignored.
+type CircuitBreaker struct {
+	opts CircuitBreakerOptions
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// circuit is the state of a single key
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// circuitOutcome is how a request affects its circuit
+type circuitOutcome int
+
+const (
+	circuitSuccess circuitOutcome = iota
+	circuitFailure
+	circuitIgnored
+)
+
+// NewCircuitBreaker creates a circuit breaker with every circuit closed
+func NewCircuitBreaker(opts *CircuitBreakerOptions) *CircuitBreaker {
+	if opts == nil {
+		opts = &CircuitBreakerOptions{}
+	}
+
+	b := &CircuitBreaker{opts: *opts, circuits: make(map[string]*circuit)}
+	if b.opts.FailureThreshold <= 0 {
+		b.opts.FailureThreshold = 5
+	}
+	if b.opts.OpenTimeout <= 0 {
+		b.opts.OpenTimeout = 30 * time.Second
+	}
+	if b.opts.HalfOpenProbes <= 0 {
+		b.opts.HalfOpenProbes = 1
+	}
+
+	return b
+}
+
+// WithCircuitBreaker makes the client check breaker before every request attempt
+func WithCircuitBreaker(breaker *CircuitBreaker) ClientOption {
+	return func(c *clientConfig) {
+		c.breaker = breaker
+	}
+}
+
+// State returns the state of the circuit for key, as reported by States
+func (b *CircuitBreaker) State(key string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if cb, ok := b.circuits[key]; ok {
+		return b.currentState(cb)
+	}
+	return CircuitClosed
+}
+
+// States returns the state of every circuit that has seen a request, keyed by
+// base URL such as "https://api.runpod.ai", or by base URL and endpoint ID
+// such as "https://api.runpod.ai/v2/abc123" with PerEndpoint
+func (b *CircuitBreaker) States() map[string]CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states := make(map[string]CircuitState, len(b.circuits))
+	for key, cb := range b.circuits {
+		states[key] = b.currentState(cb)
+	}
+	return states
+}
+
+// Healthy reports whether every circuit is closed, for use in health checks
+func (b *CircuitBreaker) Healthy() bool {
+	for _, state := range b.States() {
+		if state != CircuitClosed {
+			return false
+		}
+	}
+	return true
+}
+
+// Reset closes every circuit
+func (b *CircuitBreaker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.circuits = make(map[string]*circuit)
+}
+
+// do sends a request through the circuit for rawURL. A nil breaker sends it directly.
+func (b *CircuitBreaker) do(ctx context.Context, rawURL string, send func() (*http.Response, error)) (*http.Response, error) {
+	if b == nil {
+		return send()
+	}
+
+	key := b.key(rawURL)
+	probe, err := b.allow(key)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := send()
+
+	outcome := circuitSuccess
+	switch {
+	case ctx.Err() != nil:
+		// Cancelled by the caller, which says nothing about the API's health
+		outcome = circuitIgnored
+	case err != nil && isCircuitFailure(err):
+		outcome = circuitFailure
+	case err != nil:
+		// Failed before anything was sent, such as a missing API key
+		outcome = circuitIgnored
+	case resp.StatusCode >= 500:
+		outcome = circuitFailure
+	}
+	b.record(key, probe, outcome)
+
+	return resp, err
+}
+
+// isCircuitFailure reports whether a send error means the API could not be reached
+func isCircuitFailure(err error) bool {
+	var networkErr *NetworkError
+	var timeoutErr *TimeoutError
+	return errors.As(err, &networkErr) || errors.As(err, &timeoutErr)
+}
+
+// key returns the circuit key for a request URL
+func (b *CircuitBreaker) key(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	key := u.Scheme + "://" + u.Host
+	if b.opts.PerEndpoint && strings.HasPrefix(u.Path, "/v2/") {
+		endpointID, _, _ := strings.Cut(strings.TrimPrefix(u.Path, "/v2/"), "/")
+		key += "/v2/" + endpointID
+	}
+	return key
+}
+
+// allow admits a request or returns a *CircuitOpenError. probe reports whether
+// the request was admitted as a half-open probe.
+func (b *CircuitBreaker) allow(key string) (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb, ok := b.circuits[key]
+	if !ok {
+		cb = &circuit{}
+		b.circuits[key] = cb
+	}
+
+	if cb.state == CircuitOpen {
+		remaining := b.opts.OpenTimeout - time.Since(cb.openedAt)
+		if remaining > 0 {
+			return false, &CircuitOpenError{Key: key, RetryAfter: remaining}
+		}
+		b.transition(key, cb, CircuitHalfOpen)
+	}
+
+	if cb.state == CircuitHalfOpen {
+		if cb.probes >= b.opts.HalfOpenProbes {
+			return false, &CircuitOpenError{Key: key}
+		}
+		cb.probes++
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// record applies a request's outcome to its circuit
+func (b *CircuitBreaker) record(key string, probe bool, outcome circuitOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb := b.circuits[key]
+	if cb == nil {
+		// Reset while the request was in flight
+		return
+	}
+	if probe && cb.probes > 0 {
+		cb.probes--
+	}
+
+	switch outcome {
+	case circuitSuccess:
+		if cb.state == CircuitOpen {
+			// A late response from before the circuit opened
+			return
+		}
+		cb.failures = 0
+		if cb.state == CircuitHalfOpen && probe {
+			b.transition(key, cb, CircuitClosed)
+		}
+
+	case circuitFailure:
+		switch cb.state {
+		case CircuitClosed:
+			cb.failures++
+			if cb.failures >= b.opts.FailureThreshold {
+				b.transition(key, cb, CircuitOpen)
+			}
+		case CircuitHalfOpen:
+			if probe {
+				b.transition(key, cb, CircuitOpen)
+			}
+		}
+	}
+}
+
+// transition moves a circuit to a new state. The caller must hold b.mu.
+func (b *CircuitBreaker) transition(key string, cb *circuit, to CircuitState) {
+	from := cb.state
+	cb.state = to
+
+	switch to {
+	case CircuitOpen:
+		cb.openedAt = time.Now()
+	case CircuitHalfOpen:
+		cb.probes = 0
+	case CircuitClosed:
+		cb.failures = 0
+	}
+
+	if b.opts.OnStateChange != nil && from != to {
+		b.opts.OnStateChange(key, from, to)
+	}
+}
+
+// currentState reports an open circuit whose timeout has passed as half-open.
+// The caller must hold b.mu.
+func (b *CircuitBreaker) currentState(cb *circuit) CircuitState {
+	if cb.state == CircuitOpen && time.Since(cb.openedAt) >= b.opts.OpenTimeout {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -21,6 +22,9 @@ const (
 	// DefaultServerlessBaseURL is the base URL for serverless operations
 	DefaultServerlessBaseURL = "https://api.runpod.ai"
 
+	// DefaultGraphQLURL is the RunPod GraphQL API URL, used for operations the REST API lacks
+	DefaultGraphQLURL = "https://api.runpod.io/graphql"
+
 	// DefaultTimeout is the default HTTP client timeout
 	DefaultTimeout = 30 * time.Second
 
@@ -34,24 +38,49 @@ const (
 	RetryDelay = 1 * time.Second
 )
 
-// Client represents the RunPod API client
+// Client represents the RunPod API client. It is safe for concurrent use: its
+// settings live in an immutable snapshot that SetDebug and SetLogger replace
+// atomically, and Clone and With derive clients with different settings.
 type Client struct {
+	config atomic.Pointer[clientConfig]
+}
+
+// clientConfig is a snapshot of a client's settings. A published snapshot is
+// never modified; changes copy it and store the copy.
+type clientConfig struct {
 	// API configuration
-	APIKey            string
-	BaseURL           string
-	ServerlessBaseURL string
+	credentials       CredentialsProvider
+	baseURL           string
+	serverlessBaseURL string
+	graphQLURL        string
 
 	// HTTP client configuration
-	HTTPClient *http.Client
-	UserAgent  string
+	httpClient *http.Client
+	userAgent  string
 
 	// Client options
-	Debug            bool
-	MaxRetryAttempts int
-	RetryDelay       time.Duration
+	debug            bool
+	maxRetryAttempts int
+	retryDelay       time.Duration
 
 	// Logger for debug output
-	Logger Logger
+	logger Logger
+
+	// headers are extra request headers set with WithRequestHeader
+	headers http.Header
+
+	// breaker, if set, fails requests fast while the API is failing
+	breaker *CircuitBreaker
+
+	// hedgeDelay, if set, hedges idempotent reads (see WithHedging)
+	hedgeDelay    time.Duration
+	hedgeCounters *hedgeCounters
+
+	// maxResponseSize, if set, limits response bodies (see WithMaxResponseSize)
+	maxResponseSize int64
+
+	// offload, if set, moves large job inputs and outputs through object storage
+	offload *OffloadOptions
 }
 
 // Logger interface for custom logging
@@ -67,114 +96,192 @@ func (l *defaultLogger) Printf(format string, v ...interface{}) {
 }
 
 // ClientOption is a function type for configuring the client
-type ClientOption func(*Client)
+type ClientOption func(*clientConfig)
 
 // WithBaseURL sets a custom base URL for the API
 func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) {
-		c.BaseURL = baseURL
+	return func(c *clientConfig) {
+		c.baseURL = baseURL
 	}
 }
 
 // WithServerlessBaseURL sets a custom base URL for serverless operations
 func WithServerlessBaseURL(baseURL string) ClientOption {
-	return func(c *Client) {
-		c.ServerlessBaseURL = baseURL
+	return func(c *clientConfig) {
+		c.serverlessBaseURL = baseURL
+	}
+}
+
+// WithGraphQLURL sets a custom URL for GraphQL operations
+func WithGraphQLURL(graphQLURL string) ClientOption {
+	return func(c *clientConfig) {
+		c.graphQLURL = graphQLURL
 	}
 }
 
 // WithTimeout sets a custom timeout for HTTP requests
 func WithTimeout(timeout time.Duration) ClientOption {
-	return func(c *Client) {
-		c.HTTPClient.Timeout = timeout
+	return func(c *clientConfig) {
+		c.setTimeout(timeout)
 	}
 }
 
 // WithHTTPClient sets a custom HTTP client
 func WithHTTPClient(httpClient *http.Client) ClientOption {
-	return func(c *Client) {
-		c.HTTPClient = httpClient
+	return func(c *clientConfig) {
+		c.httpClient = httpClient
 	}
 }
 
 // WithDebug enables or disables debug logging
 func WithDebug(debug bool) ClientOption {
-	return func(c *Client) {
-		c.Debug = debug
+	return func(c *clientConfig) {
+		c.debug = debug
 	}
 }
 
 // WithUserAgent sets a custom user agent string
 func WithUserAgent(userAgent string) ClientOption {
-	return func(c *Client) {
-		c.UserAgent = userAgent
+	return func(c *clientConfig) {
+		c.userAgent = userAgent
 	}
 }
 
 // WithMaxRetryAttempts sets the maximum number of retry attempts
 func WithMaxRetryAttempts(maxAttempts int) ClientOption {
-	return func(c *Client) {
-		c.MaxRetryAttempts = maxAttempts
+	return func(c *clientConfig) {
+		c.maxRetryAttempts = maxAttempts
 	}
 }
 
 // WithRetryDelay sets the base delay between retry attempts
 func WithRetryDelay(delay time.Duration) ClientOption {
-	return func(c *Client) {
-		c.RetryDelay = delay
+	return func(c *clientConfig) {
+		c.retryDelay = delay
 	}
 }
 
 // WithLogger sets a custom logger for debug output
 func WithLogger(logger Logger) ClientOption {
-	return func(c *Client) {
-		c.Logger = logger
+	return func(c *clientConfig) {
+		c.logger = logger
 	}
 }
 
-// NewClient creates a new RunPod API client
+// NewClient creates a new RunPod API client. apiKey may only be empty when
+// WithCredentialsProvider supplies the key instead.
 func NewClient(apiKey string, opts ...ClientOption) *Client {
-	if apiKey == "" {
-		panic("API key is required")
-	}
-
-	c := &Client{
-		APIKey:            apiKey,
-		BaseURL:           DefaultBaseURL,
-		ServerlessBaseURL: DefaultServerlessBaseURL,
-		HTTPClient: &http.Client{
+	cfg := &clientConfig{
+		baseURL:           DefaultBaseURL,
+		serverlessBaseURL: DefaultServerlessBaseURL,
+		graphQLURL:        DefaultGraphQLURL,
+		httpClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
-		UserAgent:        DefaultUserAgent,
-		Debug:            false,
-		MaxRetryAttempts: MaxRetryAttempts,
-		RetryDelay:       RetryDelay,
-		Logger:           &defaultLogger{},
+		userAgent:        DefaultUserAgent,
+		debug:            false,
+		maxRetryAttempts: MaxRetryAttempts,
+		retryDelay:       RetryDelay,
+		logger:           &defaultLogger{},
+	}
+
+	if apiKey != "" {
+		cfg.credentials = StaticCredentials(apiKey)
 	}
 
 	// Apply all options
 	for _, opt := range opts {
-		opt(c)
+		opt(cfg)
 	}
 
+	if cfg.credentials == nil {
+		panic("API key is required")
+	}
+
+	c := &Client{}
+	c.config.Store(cfg)
 	return c
 }
 
+// Clone returns an independent client with this client's settings and opts
+// applied on top. Later SetDebug or SetLogger calls on either client do not
+// affect the other.
+func (c *Client) Clone(opts ...ClientOption) *Client {
+	cfg := c.snapshot().clone()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	clone := &Client{}
+	clone.config.Store(cfg)
+	return clone
+}
+
+// SetDebug enables or disables debug logging. It is safe to call while
+// requests are in flight; each request uses the setting it started with.
+func (c *Client) SetDebug(debug bool) {
+	c.update(func(cfg *clientConfig) {
+		cfg.debug = debug
+	})
+}
+
+// SetLogger replaces the logger used for debug output
+func (c *Client) SetLogger(logger Logger) {
+	c.update(func(cfg *clientConfig) {
+		cfg.logger = logger
+	})
+}
+
+// snapshot returns the current settings, which must not be modified
+func (c *Client) snapshot() *clientConfig {
+	return c.config.Load()
+}
+
+// update atomically replaces the settings with a modified copy
+func (c *Client) update(modify func(*clientConfig)) {
+	for {
+		current := c.config.Load()
+		next := current.clone()
+		modify(next)
+		if c.config.CompareAndSwap(current, next) {
+			return
+		}
+	}
+}
+
+// clone copies the settings so the copy can be modified
+func (cfg *clientConfig) clone() *clientConfig {
+	copied := *cfg
+	copied.headers = cfg.headers.Clone()
+	return &copied
+}
+
+// setTimeout sets the HTTP timeout on a copy of the HTTP client, which other
+// clients may share
+func (cfg *clientConfig) setTimeout(timeout time.Duration) {
+	httpClient := *cfg.httpClient
+	httpClient.Timeout = timeout
+	cfg.httpClient = &httpClient
+}
+
 // makeRequest performs an HTTP request with retry logic
 func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+	cfg := c.snapshot()
 	var lastErr error
 
-	for attempt := 0; attempt <= c.MaxRetryAttempts; attempt++ {
+	for attempt := 0; attempt <= cfg.maxRetryAttempts; attempt++ {
 		if attempt > 0 {
 			// Wait before retrying
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-time.After(c.RetryDelay * time.Duration(attempt)):
+			case <-time.After(cfg.retryDelay * time.Duration(attempt)):
 			}
 		}
 
-		resp, err := c.doRequest(ctx, method, endpoint, body)
+		resp, err := cfg.breaker.do(ctx, cfg.buildURL(endpoint), func() (*http.Response, error) {
+			return c.doRequest(ctx, cfg, method, endpoint, body)
+		})
 		if err != nil {
 			lastErr = err
 
@@ -183,19 +290,20 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 				return nil, err
 			}
 
-			if c.Debug {
-				c.Logger.Printf("[DEBUG] Request attempt %d failed, retrying: %v", attempt+1, err)
+			if cfg.debug {
+				cfg.logger.Printf("[DEBUG] Request attempt %d failed, retrying: %v", attempt+1, err)
 			}
 			continue
 		}
 
-		// Check if response indicates a retryable error
-		if c.isRetryableHTTPStatus(resp.StatusCode) && attempt < c.MaxRetryAttempts {
+		// Check if response indicates a retryable error. Running out of
+		// capacity is reported as a 5xx but will not change on retry.
+		if c.isRetryableHTTPStatus(resp.StatusCode) && attempt < cfg.maxRetryAttempts && !c.isNoCapacityResponse(cfg, resp) {
 			resp.Body.Close()
 			lastErr = fmt.Errorf("HTTP %d: retryable server error", resp.StatusCode)
 
-			if c.Debug {
-				c.Logger.Printf("[DEBUG] HTTP %d received, retrying attempt %d", resp.StatusCode, attempt+1)
+			if cfg.debug {
+				cfg.logger.Printf("[DEBUG] HTTP %d received, retrying attempt %d", resp.StatusCode, attempt+1)
 			}
 			continue
 		}
@@ -203,11 +311,11 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 		return resp, nil
 	}
 
-	return nil, fmt.Errorf("request failed after %d attempts: %w", c.MaxRetryAttempts+1, lastErr)
+	return nil, fmt.Errorf("request failed after %d attempts: %w", cfg.maxRetryAttempts+1, lastErr)
 }
 
 // doRequest performs a single HTTP request
-func (c *Client) doRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+func (c *Client) doRequest(ctx context.Context, cfg *clientConfig, method, endpoint string, body interface{}) (*http.Response, error) {
 	var buf io.Reader
 
 	if body != nil {
@@ -219,7 +327,7 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body in
 	}
 
 	// Determine the full URL based on endpoint
-	fullURL := c.buildURL(endpoint)
+	fullURL := cfg.buildURL(endpoint)
 
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, buf)
 	if err != nil {
@@ -227,17 +335,21 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body in
 	}
 
 	// Set headers
-	c.setRequestHeaders(req, body != nil)
+	apiKey, err := cfg.credentials.APIKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	cfg.setRequestHeaders(req, apiKey, body != nil)
 
-	if c.Debug {
-		c.Logger.Printf("[DEBUG] %s %s", method, fullURL)
+	if cfg.debug {
+		cfg.logger.Printf("[DEBUG] %s %s", method, fullURL)
 		if body != nil {
 			bodyJSON, _ := json.MarshalIndent(body, "", "  ")
-			c.Logger.Printf("[DEBUG] Request Body: %s", string(bodyJSON))
+			cfg.logger.Printf("[DEBUG] Request Body: %s", string(bodyJSON))
 		}
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := cfg.httpClient.Do(req)
 	if err != nil {
 		return nil, NewNetworkError("HTTP request failed", err)
 	}
@@ -247,54 +359,89 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body in
 
 // buildURL constructs the full URL for a given endpoint
 func (c *Client) buildURL(endpoint string) string {
+	return c.snapshot().buildURL(endpoint)
+}
+
+// buildURL constructs the full URL for a given endpoint from these settings
+func (cfg *clientConfig) buildURL(endpoint string) string {
+	// Endpoints built with buildURLWithParams are already absolute
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		return endpoint
+	}
+
 	// If endpoint starts with /v2/ or contains api.runpod.ai, it's a serverless endpoint
 	if strings.HasPrefix(endpoint, "/v2/") || strings.Contains(endpoint, "api.runpod.ai") {
 		if strings.HasPrefix(endpoint, "/v2/") {
-			return c.ServerlessBaseURL + endpoint
+			return cfg.serverlessBaseURL + endpoint
 		}
 		return endpoint // Assume it's already a full URL
 	}
 
 	// Standard REST API endpoint
-	return c.BaseURL + endpoint
+	return cfg.baseURL + endpoint
 }
 
 // setRequestHeaders sets the required headers for the request
-func (c *Client) setRequestHeaders(req *http.Request, hasBody bool) {
-	req.Header.Set("Authorization", "Bearer "+c.APIKey)
-	req.Header.Set("User-Agent", c.UserAgent)
+func (cfg *clientConfig) setRequestHeaders(req *http.Request, apiKey string, hasBody bool) {
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("User-Agent", cfg.userAgent)
 
 	if hasBody {
 		req.Header.Set("Content-Type", "application/json")
 	}
+
+	for key, values := range cfg.headers {
+		req.Header[key] = values
+	}
 }
 
-// handleResponse processes the HTTP response and handles errors
+// handleResponse processes the HTTP response and handles errors. Successful
+// responses are decoded as they stream in rather than read into memory first.
 func (c *Client) handleResponse(resp *http.Response, v interface{}) error {
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return NewNetworkError("failed to read response body", err)
-	}
-
-	if c.Debug {
-		c.Logger.Printf("[DEBUG] Response Status: %d", resp.StatusCode)
-		c.Logger.Printf("[DEBUG] Response Body: %s", string(body))
+	cfg := c.snapshot()
+	if cfg.debug {
+		cfg.logger.Printf("[DEBUG] Response Status: %d", resp.StatusCode)
 	}
 
 	// Handle error responses
 	if resp.StatusCode >= 400 {
+		body, err := cfg.readBody(resp)
+		if err != nil {
+			return err
+		}
+		cfg.logResponseBody(body)
 		return c.parseErrorResponse(resp.StatusCode, body)
 	}
 
+	body, err := cfg.bodyReader(resp)
+	if err != nil {
+		return err
+	}
+	if cfg.debug {
+		logged := &debugBody{}
+		body = io.TeeReader(body, logged)
+		defer func() {
+			cfg.logger.Printf("[DEBUG] Response Body: %s", logged)
+		}()
+	}
+
 	// Parse successful response
-	if v != nil && len(body) > 0 {
-		if err := json.Unmarshal(body, v); err != nil {
+	if v != nil {
+		if err := json.NewDecoder(body).Decode(v); err != nil && err != io.EOF {
+			if IsResponseTooLargeError(err) {
+				return err
+			}
 			return fmt.Errorf("failed to unmarshal response: %w", err)
 		}
 	}
 
+	// Drain the rest so the connection can be reused
+	if _, err := io.Copy(io.Discard, body); err != nil && IsResponseTooLargeError(err) {
+		return err
+	}
+
 	return nil
 }
 
@@ -366,14 +513,31 @@ func (c *Client) isRetryableError(err error) bool {
 		return true
 	}
 
-	// API errors with 5xx status codes are retryable
+	// API errors with 5xx status codes are retryable, unless no capacity is left
 	if apiErr, ok := err.(*APIError); ok {
-		return apiErr.IsServerError()
+		return apiErr.IsServerError() && !apiErr.IsNoCapacity()
 	}
 
 	return false
 }
 
+// isNoCapacityResponse reports whether a 5xx response says no capacity is
+// available. The body is read and replaced so it can still be handled.
+func (c *Client) isNoCapacityResponse(cfg *clientConfig, resp *http.Response) bool {
+	if resp.StatusCode < 500 {
+		return false
+	}
+
+	body, err := cfg.readBody(resp)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	return IsNoCapacityError(c.parseErrorResponse(resp.StatusCode, body))
+}
+
 // isRetryableHTTPStatus determines if an HTTP status code should trigger a retry
 func (c *Client) isRetryableHTTPStatus(statusCode int) bool {
 	switch statusCode {
@@ -510,25 +674,65 @@ func (c *Client) Patch(ctx context.Context, endpoint string, body interface{}, r
 	return c.handleResponse(resp, result)
 }
 
-// GetAPIKey returns the configured API key (masked for security)
+// GetAPIKey returns the current API key (masked for security), or an empty
+// string if the credentials provider has none
 func (c *Client) GetAPIKey() string {
-	if len(c.APIKey) <= 8 {
+	apiKey, err := c.snapshot().credentials.APIKey(context.Background())
+	if err != nil {
+		return ""
+	}
+	if len(apiKey) <= 8 {
 		return "***"
 	}
-	return c.APIKey[:4] + "***" + c.APIKey[len(c.APIKey)-4:]
+	return apiKey[:4] + "***" + apiKey[len(apiKey)-4:]
 }
 
 // GetBaseURL returns the configured base URL
 func (c *Client) GetBaseURL() string {
-	return c.BaseURL
+	return c.snapshot().baseURL
 }
 
 // GetServerlessBaseURL returns the configured serverless base URL
 func (c *Client) GetServerlessBaseURL() string {
-	return c.ServerlessBaseURL
+	return c.snapshot().serverlessBaseURL
+}
+
+// GetGraphQLURL returns the configured GraphQL URL
+func (c *Client) GetGraphQLURL() string {
+	return c.snapshot().graphQLURL
+}
+
+// GetHTTPClient returns the HTTP client used for requests
+func (c *Client) GetHTTPClient() *http.Client {
+	return c.snapshot().httpClient
+}
+
+// GetTimeout returns the HTTP request timeout
+func (c *Client) GetTimeout() time.Duration {
+	return c.snapshot().httpClient.Timeout
+}
+
+// GetUserAgent returns the configured user agent string
+func (c *Client) GetUserAgent() string {
+	return c.snapshot().userAgent
+}
+
+// GetMaxRetryAttempts returns the maximum number of retry attempts
+func (c *Client) GetMaxRetryAttempts() int {
+	return c.snapshot().maxRetryAttempts
+}
+
+// GetRetryDelay returns the base delay between retry attempts
+func (c *Client) GetRetryDelay() time.Duration {
+	return c.snapshot().retryDelay
+}
+
+// GetLogger returns the logger used for debug output
+func (c *Client) GetLogger() Logger {
+	return c.snapshot().logger
 }
 
 // IsDebugEnabled returns whether debug mode is enabled
 func (c *Client) IsDebugEnabled() bool {
-	return c.Debug
+	return c.snapshot().debug
 }
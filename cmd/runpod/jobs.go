@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+var jobCommands = map[string]command{
+	"run":    {usage: "run <endpoint-id> [<input-json>|-] [-file <path>] [-sync|-wait]", summary: "Submit a job", run: jobsRun},
+	"status": {usage: "status <endpoint-id> <job-id>", summary: "Show a job", run: jobsStatus},
+	"cancel": {usage: "cancel <endpoint-id> <job-id>", summary: "Cancel a job", run: jobsCancel},
+	"retry":  {usage: "retry <endpoint-id> <job-id>", summary: "Retry a failed job", run: jobsRetry},
+	"purge":  {usage: "purge <endpoint-id>", summary: "Remove all queued jobs", run: jobsPurge},
+	"health": {usage: "health <endpoint-id>", summary: "Show endpoint health", run: jobsHealth},
+	"wait":   {usage: "wait <endpoint-id> <job-id> [-timeout <duration>]", summary: "Wait for a job to finish", run: jobsWait},
+	"batch":  {usage: "batch <endpoint-id> <inputs.jsonl> <results.jsonl> [-concurrency <n>] [-retry-failed]", summary: "Run a JSONL file of inputs, resuming earlier progress", run: jobsBatch},
+}
+
+func jobsRun(c *cli, args []string) error {
+	fs := c.flagSet("run")
+	file := fs.String("file", "", "read the job input JSON from a file")
+	sync := fs.Bool("sync", false, "use /runsync and wait for the result")
+	wait := fs.Bool("wait", false, "submit asynchronously and wait for the result")
+	timeout := fs.Duration("timeout", 10*time.Minute, "how long -wait waits")
+
+	positional, err := c.parse(fs, args, 1, 2)
+	if err != nil {
+		return err
+	}
+
+	var raw []byte
+	switch {
+	case *file != "":
+		raw, err = os.ReadFile(*file)
+	case len(positional) == 2 && positional[1] != "-":
+		raw = []byte(positional[1])
+	default:
+		raw, err = io.ReadAll(c.stdin)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read job input: %w", err)
+	}
+
+	var input interface{}
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return &usageError{msg: fmt.Sprintf("job input is not valid JSON: %v", err)}
+	}
+
+	endpointID := positional[0]
+	var job *runpod.Job
+	switch {
+	case *sync:
+		job, err = c.client.RunSync(c.ctx, endpointID, input)
+	case *wait:
+		job, err = c.client.RunAndWait(c.ctx, endpointID, input, *timeout)
+	default:
+		job, err = c.client.RunAsync(c.ctx, endpointID, input)
+	}
+	if err != nil {
+		return err
+	}
+	return c.printJob(job)
+}
+
+func jobsStatus(c *cli, args []string) error {
+	positional, err := c.parse(c.flagSet("status"), args, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	job, err := c.client.GetJobStatus(c.ctx, positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+	return c.printJob(job)
+}
+
+func jobsCancel(c *cli, args []string) error {
+	positional, err := c.parse(c.flagSet("cancel"), args, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.CancelJob(c.ctx, positional[0], positional[1]); err != nil {
+		return err
+	}
+	return c.printResult(positional[1], "cancelled")
+}
+
+func jobsRetry(c *cli, args []string) error {
+	positional, err := c.parse(c.flagSet("retry"), args, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	job, err := c.client.RetryJob(c.ctx, positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+	return c.printJob(job)
+}
+
+func jobsPurge(c *cli, args []string) error {
+	positional, err := c.parse(c.flagSet("purge"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.PurgeQueue(c.ctx, positional[0]); err != nil {
+		return err
+	}
+	return c.printResult(positional[0], "queue purged")
+}
+
+func jobsHealth(c *cli, args []string) error {
+	positional, err := c.parse(c.flagSet("health"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	health, err := c.client.GetHealth(c.ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	if c.output == "json" {
+		return c.printJSON(health)
+	}
+	return c.printFields([][2]string{
+		{"Status", health.Status},
+		{"Jobs in queue", fmt.Sprint(health.JobsInQueue)},
+		{"Workers active", fmt.Sprint(health.WorkersActive)},
+		{"Workers idle", fmt.Sprint(health.WorkersIdle)},
+		{"Workers total", fmt.Sprint(health.WorkersTotal)},
+	})
+}
+
+func jobsWait(c *cli, args []string) error {
+	fs := c.flagSet("wait")
+	timeout := fs.Duration("timeout", 10*time.Minute, "how long to wait")
+
+	positional, err := c.parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	job, err := c.client.WaitForJobCompletion(c.ctx, positional[0], positional[1], *timeout)
+	if err != nil {
+		return err
+	}
+	return c.printJob(job)
+}
+
+func jobsBatch(c *cli, args []string) error {
+	fs := c.flagSet("batch")
+	opts := &runpod.BatchOptions{}
+	fs.IntVar(&opts.Concurrency, "concurrency", 4, "jobs in flight at once")
+	fs.BoolVar(&opts.RetryFailed, "retry-failed", false, "resubmit lines recorded as failed")
+	fs.DurationVar(&opts.MaxWaitTime, "timeout", 30*time.Minute, "how long to wait for each job")
+
+	positional, err := c.parse(fs, args, 3, 3)
+	if err != nil {
+		return err
+	}
+
+	report, err := c.client.RunBatchFile(c.ctx, positional[0], positional[1], positional[2], opts)
+	if report != nil {
+		if c.output == "json" {
+			if printErr := c.printJSON(report); printErr != nil {
+				return printErr
+			}
+		} else {
+			fmt.Fprintf(c.stdout, "%d lines: %d completed, %d failed, %d pending, %d skipped (%d submitted, %d resumed)\n",
+				report.Total, report.Completed, report.Failed, report.Pending, report.Skipped, report.Submitted, report.Resumed)
+		}
+	}
+	return err
+}
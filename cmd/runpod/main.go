@@ -0,0 +1,238 @@
+// Command runpod manages RunPod pods, serverless jobs and secrets from the
+// command line.
+//
+// Usage:
+//
+//	runpod pods list [-status RUNNING] [-o json]
+//	runpod jobs run <endpoint> '{"prompt": "hi"}' -wait
+//	runpod secrets set <name> <value>
+//
+// Settings come from a profile in ~/.runpod/config.toml (selected with
+// -profile or RUNPOD_PROFILE), falling back to RUNPOD_* environment variables,
+// which may also be set in a .env file in the working directory.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/cozy-creator/runpod-go-library"
+	"github.com/joho/godotenv"
+)
+
+// command is a single subcommand such as "pods list"
+type command struct {
+	usage   string
+	summary string
+	run     func(c *cli, args []string) error
+}
+
+// groups maps each command group to its subcommands
+var groups = map[string]map[string]command{
+	"pods":    podCommands,
+	"jobs":    jobCommands,
+	"secrets": secretCommands,
+}
+
+// usageError is returned for invalid arguments and exits with status 2
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// cli holds the options shared by every command and the client built from them
+type cli struct {
+	ctx    context.Context
+	stdout io.Writer
+	stderr io.Writer
+	stdin  io.Reader
+
+	output        string
+	profile       string
+	apiKey        string
+	baseURL       string
+	serverlessURL string
+	debug         bool
+
+	client *runpod.Client
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run executes the command line and returns the process exit status
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	// A missing .env file is fine, the key may come from the environment
+	_ = godotenv.Load()
+
+	c := &cli{ctx: ctx, stdin: stdin, stdout: stdout, stderr: stderr}
+
+	if len(args) < 2 {
+		c.printUsage(args)
+		return 2
+	}
+
+	cmds, ok := groups[args[0]]
+	if !ok {
+		c.printUsage(nil)
+		return 2
+	}
+	cmd, ok := cmds[args[1]]
+	if !ok {
+		c.printUsage(args[:1])
+		return 2
+	}
+
+	err := cmd.run(c, args[2:])
+	var usageErr *usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "Error: %v\nUsage: runpod %s %s\n", err, args[0], cmd.usage)
+		return 2
+	default:
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+}
+
+// printUsage lists the available groups, or the commands of one group
+func (c *cli) printUsage(args []string) {
+	if len(args) > 0 {
+		if cmds, ok := groups[args[0]]; ok {
+			fmt.Fprintf(c.stderr, "Usage: runpod %s <command> [flags]\n\nCommands:\n", args[0])
+			for _, name := range sortedNames(cmds) {
+				fmt.Fprintf(c.stderr, "  %-10s %s\n", name, cmds[name].summary)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(c.stderr, "Usage: runpod <pods|jobs|secrets> <command> [flags]\n\nRun 'runpod <group>' to list its commands.\n")
+}
+
+// flagSet returns a flag set with the options every command accepts
+func (c *cli) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.StringVar(&c.output, "o", "table", "output format: table or json")
+	fs.StringVar(&c.profile, "profile", "", "config profile (defaults to RUNPOD_PROFILE or default)")
+	fs.StringVar(&c.apiKey, "api-key", "", "RunPod API key (defaults to the profile or RUNPOD_API_KEY)")
+	fs.StringVar(&c.baseURL, "base-url", "", "override the REST API base URL")
+	fs.StringVar(&c.serverlessURL, "serverless-url", "", "override the serverless API base URL")
+	fs.BoolVar(&c.debug, "debug", false, "log HTTP requests")
+	return fs
+}
+
+// parse parses flags placed anywhere among the arguments, checks that between
+// minArgs and maxArgs positional arguments remain and creates the client.
+// A negative maxArgs allows any number.
+func (c *cli) parse(fs *flag.FlagSet, args []string, minArgs, maxArgs int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &usageError{msg: err.Error()}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	if len(positional) < minArgs || (maxArgs >= 0 && len(positional) > maxArgs) {
+		return nil, &usageError{msg: "wrong number of arguments"}
+	}
+	if c.output != "table" && c.output != "json" {
+		return nil, &usageError{msg: fmt.Sprintf("unknown output format %q", c.output)}
+	}
+
+	profile, err := runpod.LoadProfile(c.profile)
+	if err != nil {
+		return nil, err
+	}
+	if c.apiKey != "" {
+		profile.APIKey = c.apiKey
+	}
+
+	var opts []runpod.ClientOption
+	if c.debug {
+		opts = append(opts, runpod.WithDebug(true))
+	}
+	if c.baseURL != "" {
+		opts = append(opts, runpod.WithBaseURL(c.baseURL))
+	}
+	if c.serverlessURL != "" {
+		opts = append(opts, runpod.WithServerlessBaseURL(c.serverlessURL))
+	}
+
+	c.client, err = profile.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return positional, nil
+}
+
+// stringList is a flag accepting comma-separated values, repeatable
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*s = append(*s, item)
+		}
+	}
+	return nil
+}
+
+// envFlag is a repeatable KEY=VALUE flag
+type envFlag map[string]string
+
+func (e envFlag) String() string {
+	return strings.Join(sortedNames(e), ",")
+}
+
+func (e envFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("expected KEY=VALUE, got %q", value)
+	}
+	e[key] = val
+	return nil
+}
+
+// sortedNames returns the keys of a map in sorted order
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
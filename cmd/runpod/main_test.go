@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cozy-creator/runpod-go-library"
+	"github.com/cozy-creator/runpod-go-library/emulator"
+)
+
+// runCLI runs the command line against the given servers and returns its exit status and output
+func runCLI(t *testing.T, restURL, serverlessURL, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("RUNPOD_CONFIG", "")
+	t.Setenv("RUNPOD_PROFILE", "")
+	t.Setenv("RUNPOD_API_KEY", "test-key")
+	args = append(args, "-base-url", restURL, "-serverless-url", serverlessURL)
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestPodsCommands(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/pods":
+			json.NewEncoder(w).Encode(map[string]interface{}{"pods": []*runpod.Pod{
+				{ID: "pod-1", Name: "trainer", DesiredStatus: "RUNNING", ImageName: "pytorch", CostPerHour: 0.79},
+			}})
+		case r.Method == "POST" && r.URL.Path == "/pods/pod-1/stop":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"message": "pod not found"})
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		want     string
+	}{
+		{name: "list table", args: []string{"pods", "list"}, want: "pod-1  trainer  RUNNING"},
+		{name: "list json", args: []string{"pods", "list", "-o", "json"}, want: `"id": "pod-1"`},
+		{name: "stop", args: []string{"pods", "stop", "pod-1"}, want: "pod-1 stopped"},
+		{name: "get missing", args: []string{"pods", "get", "pod-2"}, wantCode: 1},
+		{name: "missing argument", args: []string{"pods", "get"}, wantCode: 2},
+		{name: "bad output format", args: []string{"pods", "list", "-o", "yaml"}, wantCode: 2},
+		{name: "unknown command", args: []string{"pods", "explode"}, wantCode: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runCLI(t, server.URL, server.URL, "", tt.args...)
+			if code != tt.wantCode {
+				t.Fatalf("exit code = %d, want %d (stderr: %s)", code, tt.wantCode, stderr)
+			}
+			if !strings.Contains(stdout, tt.want) {
+				t.Errorf("stdout = %q, want it to contain %q", stdout, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecretsListFollowsPages(t *testing.T) {
+	secrets := make([]*runpod.Secret, 150)
+	for i := range secrets {
+		secrets[i] = &runpod.Secret{ID: fmt.Sprintf("sec-%d", i), Name: fmt.Sprintf("SECRET_%d", i)}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		page := secrets[min(offset, len(secrets)):min(offset+limit, len(secrets))]
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"secrets": page})
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLI(t, server.URL, server.URL, "", "secrets", "list", "-o", "json")
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	var listed []*runpod.Secret
+	if err := json.Unmarshal([]byte(stdout), &listed); err != nil {
+		t.Fatalf("output is not a secret list: %v", err)
+	}
+	if len(listed) != len(secrets) {
+		t.Errorf("listed %d secrets, want %d", len(listed), len(secrets))
+	}
+}
+
+func TestJobsRunAgainstEmulator(t *testing.T) {
+	emu := emulator.New(func(ctx context.Context, job *runpod.Job) (interface{}, error) {
+		return job.Input, nil
+	}, nil)
+	server := httptest.NewServer(emu)
+	defer func() {
+		server.Close()
+		emu.Close()
+	}()
+
+	code, stdout, stderr := runCLI(t, server.URL, server.URL, `{"prompt": "hi"}`, "jobs", "run", "local", "-", "-sync", "-o", "json")
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	var job runpod.Job
+	if err := json.Unmarshal([]byte(stdout), &job); err != nil {
+		t.Fatalf("output is not a job: %v", err)
+	}
+	if job.Status != string(runpod.JobStatusCompleted) {
+		t.Errorf("job status = %v, want COMPLETED", job.Status)
+	}
+	if output, _ := job.Output.(map[string]interface{}); output["prompt"] != "hi" {
+		t.Errorf("job output = %v, want the input echoed", job.Output)
+	}
+
+	code, stdout, _ = runCLI(t, server.URL, server.URL, "", "jobs", "status", "local", job.ID)
+	if code != 0 || !strings.Contains(stdout, "COMPLETED") {
+		t.Errorf("jobs status = %d %q, want COMPLETED", code, stdout)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// printJSON writes v as indented JSON
+func (c *cli) printJSON(v interface{}) error {
+	encoder := json.NewEncoder(c.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// printTable writes rows under a header, aligned in columns
+func (c *cli) printTable(header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// printFields writes name/value pairs, one per line
+func (c *cli) printFields(fields [][2]string) error {
+	tw := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	for _, field := range fields {
+		fmt.Fprintf(tw, "%s:\t%s\n", field[0], field[1])
+	}
+	return tw.Flush()
+}
+
+// printResult reports the outcome of an action that returns no resource
+func (c *cli) printResult(id, result string) error {
+	if c.output == "json" {
+		return c.printJSON(map[string]string{"id": id, "result": result})
+	}
+	_, err := fmt.Fprintf(c.stdout, "%s %s\n", id, result)
+	return err
+}
+
+// printPods writes pods as a table or JSON
+func (c *cli) printPods(pods []*runpod.Pod) error {
+	if c.output == "json" {
+		if pods == nil {
+			pods = []*runpod.Pod{}
+		}
+		return c.printJSON(pods)
+	}
+
+	rows := make([][]string, 0, len(pods))
+	for _, pod := range pods {
+		rows = append(rows, []string{
+			pod.ID,
+			pod.Name,
+			pod.Status(),
+			gpuSummary(pod),
+			pod.ImageName,
+			fmt.Sprintf("$%.3f", pod.CostPerHour),
+		})
+	}
+	return c.printTable([]string{"ID", "NAME", "STATUS", "GPU", "IMAGE", "COST/HR"}, rows)
+}
+
+// printPod writes a single pod's details
+func (c *cli) printPod(pod *runpod.Pod) error {
+	if c.output == "json" {
+		return c.printJSON(pod)
+	}
+
+	fields := [][2]string{
+		{"ID", pod.ID},
+		{"Name", pod.Name},
+		{"Status", pod.Status()},
+		{"Image", pod.ImageName},
+		{"GPU", gpuSummary(pod)},
+		{"Data center", pod.DataCenterID()},
+		{"Interruptible", fmt.Sprint(pod.Interruptible)},
+		{"Cost/hr", fmt.Sprintf("$%.3f", pod.CostPerHour)},
+		{"Created", formatTime(pod.CreatedAt)},
+		{"Last started", formatTime(pod.LastStartedAt)},
+	}
+	if pod.PublicIP != "" {
+		fields = append(fields, [2]string{"Public IP", pod.PublicIP})
+	}
+	if len(pod.Ports) > 0 {
+		fields = append(fields, [2]string{"Ports", strings.Join(pod.Ports, ", ")})
+	}
+	return c.printFields(fields)
+}
+
+// printJob writes a job's status and output
+func (c *cli) printJob(job *runpod.Job) error {
+	if c.output == "json" {
+		return c.printJSON(job)
+	}
+
+	fields := [][2]string{
+		{"ID", job.ID},
+		{"Status", job.Status},
+	}
+	if job.ExecutionTime > 0 {
+		fields = append(fields, [2]string{"Execution", (time.Duration(job.ExecutionTime) * time.Millisecond).String()})
+	}
+	if job.Error != "" {
+		fields = append(fields, [2]string{"Error", job.Error})
+	}
+	if job.Output != nil {
+		output, err := json.Marshal(job.Output)
+		if err != nil {
+			return fmt.Errorf("failed to marshal job output: %w", err)
+		}
+		fields = append(fields, [2]string{"Output", string(output)})
+	}
+	return c.printFields(fields)
+}
+
+// gpuSummary describes a pod's GPUs as "2x NVIDIA A40"
+func gpuSummary(pod *runpod.Pod) string {
+	gpuType := pod.GPUTypeID()
+	if pod.GPU != nil && pod.GPU.DisplayName != "" {
+		gpuType = pod.GPU.DisplayName
+	}
+	if gpuType == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%dx %s", pod.GPUCount, gpuType)
+}
+
+// formatTime formats an optional API timestamp
+func formatTime(t *runpod.JSONTime) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
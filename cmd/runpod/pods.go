@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+var podCommands = map[string]command{
+	"create":    {usage: "create -image <image> [-name <name>] [-gpu <type>] [flags]", summary: "Create a pod", run: podsCreate},
+	"list":      {usage: "list [-status <status>] [-name <name>]", summary: "List pods", run: podsList},
+	"get":       {usage: "get <pod-id>", summary: "Show a pod", run: podsGet},
+	"stop":      {usage: "stop <pod-id>", summary: "Stop a pod", run: podsStop},
+	"resume":    {usage: "resume <pod-id>", summary: "Resume a stopped pod", run: podsResume},
+	"terminate": {usage: "terminate <pod-id>", summary: "Terminate a pod", run: podsTerminate},
+	"logs":      {usage: "logs <pod-id> [-f]", summary: "Print or follow pod logs", run: podsLogs},
+	"cost":      {usage: "cost [-group-by namePrefix|image|gpuType] [-csv pods|groups]", summary: "Report accrued and projected spend", run: podsCost},
+}
+
+func podsCreate(c *cli, args []string) error {
+	fs := c.flagSet("create")
+	req := &runpod.CreatePodRequest{Env: map[string]string{}}
+	var gpuTypes, dataCenters, ports stringList
+	var bid float64
+	fs.StringVar(&req.Name, "name", "", "pod name")
+	fs.StringVar(&req.ImageName, "image", "", "container image (required)")
+	fs.Var(&gpuTypes, "gpu", "GPU type ID, comma-separated or repeated for alternatives")
+	fs.IntVar(&req.GPUCount, "gpu-count", 1, "number of GPUs")
+	fs.IntVar(&req.ContainerDiskInGB, "disk", 20, "container disk in GB")
+	fs.IntVar(&req.VolumeInGB, "volume", 0, "volume size in GB")
+	fs.StringVar(&req.VolumeMountPath, "mount", "", "volume mount path")
+	fs.Var(&dataCenters, "datacenter", "data center ID, comma-separated or repeated")
+	fs.Var(&ports, "ports", "exposed ports such as 8888/http, comma-separated or repeated")
+	fs.Var(envFlag(req.Env), "env", "environment variable KEY=VALUE, repeatable")
+	fs.StringVar(&req.CloudType, "cloud", "", "SECURE or COMMUNITY")
+	fs.StringVar(&req.TemplateID, "template", "", "template ID")
+	fs.Float64Var(&bid, "bid", 0, "create an interruptible spot pod bidding this much per GPU per hour")
+
+	if _, err := c.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	req.GPUTypeIDs = gpuTypes
+	req.DataCenterIDs = dataCenters
+	req.Ports = ports
+
+	var pod *runpod.Pod
+	var err error
+	if bid > 0 {
+		pod, err = c.client.CreateSpotPod(c.ctx, req, bid)
+	} else {
+		pod, err = c.client.CreatePod(c.ctx, req)
+	}
+	if err != nil {
+		return err
+	}
+	return c.printPod(pod)
+}
+
+func podsList(c *cli, args []string) error {
+	fs := c.flagSet("list")
+	filter := &runpod.PodFilter{}
+	fs.StringVar(&filter.Status, "status", "", "only pods with this status, such as RUNNING")
+	fs.StringVar(&filter.Name, "name", "", "only pods with this exact name")
+	fs.StringVar(&filter.NamePrefix, "prefix", "", "only pods whose name starts with this prefix")
+	fs.StringVar(&filter.GPUTypeID, "gpu", "", "only pods on this GPU type")
+
+	if _, err := c.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	pods, err := c.client.ListPodsFiltered(c.ctx, filter)
+	if err != nil {
+		return err
+	}
+	return c.printPods(pods)
+}
+
+func podsGet(c *cli, args []string) error {
+	positional, err := c.parse(c.flagSet("get"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	pod, err := c.client.GetPod(c.ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return c.printPod(pod)
+}
+
+func podsStop(c *cli, args []string) error {
+	positional, err := c.parse(c.flagSet("stop"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.StopPod(c.ctx, positional[0]); err != nil {
+		return err
+	}
+	return c.printResult(positional[0], "stopped")
+}
+
+func podsResume(c *cli, args []string) error {
+	positional, err := c.parse(c.flagSet("resume"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	pod, err := c.client.ResumePod(c.ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return c.printPod(pod)
+}
+
+func podsTerminate(c *cli, args []string) error {
+	positional, err := c.parse(c.flagSet("terminate"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.TerminatePod(c.ctx, positional[0]); err != nil {
+		return err
+	}
+	return c.printResult(positional[0], "terminated")
+}
+
+func podsLogs(c *cli, args []string) error {
+	fs := c.flagSet("logs")
+	follow := fs.Bool("f", false, "follow the log until interrupted")
+
+	positional, err := c.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	if !*follow {
+		logs, err := c.client.GetPodLogs(c.ctx, positional[0])
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(c.stdout, logs)
+		return err
+	}
+
+	lines, errs := c.client.TailPodLogs(c.ctx, positional[0], nil)
+	for line := range lines {
+		fmt.Fprintln(c.stdout, line.Text)
+	}
+	if err := <-errs; err != nil && c.ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+func podsCost(c *cli, args []string) error {
+	fs := c.flagSet("cost")
+	opts := &runpod.CostReportOptions{}
+	groupBy := fs.String("group-by", string(runpod.CostGroupByNamePrefix), "namePrefix, image or gpuType")
+	csvOut := fs.String("csv", "", "write CSV rows of pods or groups instead")
+
+	if _, err := c.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	opts.GroupBy = runpod.CostGroupBy(*groupBy)
+	switch opts.GroupBy {
+	case runpod.CostGroupByNamePrefix, runpod.CostGroupByImage, runpod.CostGroupByGPUType:
+	default:
+		return &usageError{msg: fmt.Sprintf("unknown grouping %q", *groupBy)}
+	}
+	if *csvOut != "" && *csvOut != "pods" && *csvOut != "groups" {
+		return &usageError{msg: fmt.Sprintf("unknown CSV content %q", *csvOut)}
+	}
+
+	report, err := c.client.GetCostReport(c.ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *csvOut == "pods":
+		return report.WriteCSV(c.stdout)
+	case *csvOut == "groups":
+		return report.WriteGroupsCSV(c.stdout)
+	case c.output == "json":
+		return report.WriteJSON(c.stdout)
+	}
+
+	rows := make([][]string, 0, len(report.Groups)+1)
+	for _, group := range report.Groups {
+		rows = append(rows, []string{
+			group.Key,
+			fmt.Sprint(group.Pods),
+			fmt.Sprintf("$%.3f", group.HourlyRate),
+			fmt.Sprintf("$%.2f", group.Accrued),
+			fmt.Sprintf("$%.2f", group.ProjectedDaily),
+		})
+	}
+	rows = append(rows, []string{
+		"TOTAL",
+		fmt.Sprint(len(report.Pods)),
+		fmt.Sprintf("$%.3f", report.HourlyRate),
+		fmt.Sprintf("$%.2f", report.Accrued),
+		fmt.Sprintf("$%.2f", report.ProjectedDaily),
+	})
+	return c.printTable([]string{"GROUP", "PODS", "COST/HR", "ACCRUED", "PER DAY"}, rows)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+var secretCommands = map[string]command{
+	"list":   {usage: "list", summary: "List secret names", run: secretsList},
+	"set":    {usage: "set <name> [<value>|-]", summary: "Create or update a secret", run: secretsSet},
+	"delete": {usage: "delete <name>", summary: "Delete a secret", run: secretsDelete},
+}
+
+func secretsList(c *cli, args []string) error {
+	if _, err := c.parse(c.flagSet("list"), args, 0, 0); err != nil {
+		return err
+	}
+
+	secrets := []*runpod.Secret{}
+	for secret, err := range c.client.ListSecretsAll(c.ctx) {
+		if err != nil {
+			return err
+		}
+		secrets = append(secrets, secret)
+	}
+
+	if c.output == "json" {
+		return c.printJSON(secrets)
+	}
+
+	rows := make([][]string, 0, len(secrets))
+	for _, secret := range secrets {
+		rows = append(rows, []string{secret.ID, secret.Name})
+	}
+	return c.printTable([]string{"ID", "NAME"}, rows)
+}
+
+func secretsSet(c *cli, args []string) error {
+	positional, err := c.parse(c.flagSet("set"), args, 1, 2)
+	if err != nil {
+		return err
+	}
+
+	// Reading from stdin keeps the value out of shell history
+	var value string
+	if len(positional) == 2 && positional[1] != "-" {
+		value = positional[1]
+	} else {
+		raw, err := io.ReadAll(c.stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read secret value: %w", err)
+		}
+		value = strings.TrimRight(string(raw), "\r\n")
+	}
+
+	if err := c.client.CreateOrUpdateSecret(c.ctx, positional[0], value); err != nil {
+		return err
+	}
+	return c.printResult(positional[0], "set")
+}
+
+func secretsDelete(c *cli, args []string) error {
+	positional, err := c.parse(c.flagSet("delete"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.DeleteSecret(c.ctx, positional[0]); err != nil {
+		return err
+	}
+	return c.printResult(positional[0], "deleted")
+}
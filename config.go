@@ -0,0 +1,317 @@
+package runpod
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultProfile is the profile used when none is named
+const DefaultProfile = "default"
+
+// Profile is a named set of client settings. Zero values are unset and leave
+// the client defaults in place; MaxRetries and Debug are pointers so that 0 and
+// false can be set explicitly.
+type Profile struct {
+	Name              string
+	APIKey            string
+	BaseURL           string
+	ServerlessBaseURL string
+	Timeout           time.Duration
+	MaxRetries        *int
+	RetryDelay        time.Duration
+	Debug             *bool
+}
+
+// ConfigPath returns the profile file location: RUNPOD_CONFIG if set,
+// otherwise ~/.runpod/config.toml
+func ConfigPath() string {
+	if path := os.Getenv("RUNPOD_CONFIG"); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".runpod", "config.toml")
+}
+
+// LoadProfile resolves a profile from the config file, filling settings the
+// profile leaves unset from RUNPOD_* environment variables. An empty name
+// selects RUNPOD_PROFILE, or DefaultProfile.
+//
+// A missing config file is not an error, so the environment alone is enough.
+// Naming a profile that the file does not define is.
+func LoadProfile(name string) (*Profile, error) {
+	if name == "" {
+		name = os.Getenv("RUNPOD_PROFILE")
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	profile := &Profile{Name: name}
+
+	path := ConfigPath()
+	profiles, err := LoadProfiles(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && os.Getenv("RUNPOD_CONFIG") == "":
+		// No config file, rely on the environment
+	case err != nil:
+		return nil, err
+	default:
+		if found, ok := profiles[name]; ok {
+			profile = found
+		} else if name != DefaultProfile {
+			return nil, fmt.Errorf("profile %q not found in %s", name, path)
+		}
+	}
+
+	if err := profile.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// LoadProfiles parses a config file of named profiles, returning an error
+// wrapping os.ErrNotExist if it does not exist. The file uses a subset of TOML:
+//
+//	# keys before any section belong to the default profile
+//	api_key = "rpa_..."
+//
+//	[staging]
+//	api_key = "rpa_..."
+//	base_url = "https://rest.runpod.io/v1"
+//	serverless_base_url = "https://api.runpod.ai"
+//	timeout = "60s"
+//	max_retries = 5
+//	retry_delay = "2s"
+//	debug = true
+func LoadProfiles(path string) (map[string]*Profile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	profiles := make(map[string]*Profile)
+	current := &Profile{Name: DefaultProfile}
+	profiles[DefaultProfile] = current
+
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%s:%d: unterminated section header", path, lineNum)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			name = strings.TrimPrefix(name, "profile.")
+			if name == "" {
+				return nil, fmt.Errorf("%s:%d: empty profile name", path, lineNum)
+			}
+
+			if existing, ok := profiles[name]; ok {
+				current = existing
+			} else {
+				current = &Profile{Name: name}
+				profiles[name] = current
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, lineNum)
+		}
+
+		value, err := unquoteConfigValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+		if err := current.set(strings.TrimSpace(key), value); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// NewClientFromProfile creates a client from a named profile (see LoadProfile).
+// Unlike NewClient it returns an error instead of panicking when no API key is configured.
+func NewClientFromProfile(name string, opts ...ClientOption) (*Client, error) {
+	profile, err := LoadProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	return profile.NewClient(opts...)
+}
+
+// NewClient creates a client from the profile's settings. opts are applied
+// after the profile, so they take precedence.
+func (p *Profile) NewClient(opts ...ClientOption) (*Client, error) {
+	if p.APIKey == "" {
+		return nil, NewValidationError("APIKey", fmt.Sprintf("is required: set api_key in profile %q or RUNPOD_API_KEY", p.Name))
+	}
+
+	return NewClient(p.APIKey, append(p.ClientOptions(), opts...)...), nil
+}
+
+// ClientOptions returns the options that apply the profile's settings
+func (p *Profile) ClientOptions() []ClientOption {
+	var opts []ClientOption
+
+	if p.BaseURL != "" {
+		opts = append(opts, WithBaseURL(p.BaseURL))
+	}
+	if p.ServerlessBaseURL != "" {
+		opts = append(opts, WithServerlessBaseURL(p.ServerlessBaseURL))
+	}
+	if p.Timeout > 0 {
+		opts = append(opts, WithTimeout(p.Timeout))
+	}
+	if p.MaxRetries != nil {
+		opts = append(opts, WithMaxRetryAttempts(*p.MaxRetries))
+	}
+	if p.RetryDelay > 0 {
+		opts = append(opts, WithRetryDelay(p.RetryDelay))
+	}
+	if p.Debug != nil {
+		opts = append(opts, WithDebug(*p.Debug))
+	}
+
+	return opts
+}
+
+// applyEnv fills unset settings from RUNPOD_* environment variables
+func (p *Profile) applyEnv() error {
+	for _, setting := range []struct {
+		env, key string
+		unset    bool
+	}{
+		{"RUNPOD_API_KEY", "api_key", p.APIKey == ""},
+		{"RUNPOD_BASE_URL", "base_url", p.BaseURL == ""},
+		{"RUNPOD_SERVERLESS_BASE_URL", "serverless_base_url", p.ServerlessBaseURL == ""},
+		{"RUNPOD_TIMEOUT", "timeout", p.Timeout == 0},
+		{"RUNPOD_MAX_RETRIES", "max_retries", p.MaxRetries == nil},
+		{"RUNPOD_RETRY_DELAY", "retry_delay", p.RetryDelay == 0},
+		{"RUNPOD_DEBUG", "debug", p.Debug == nil},
+	} {
+		value := os.Getenv(setting.env)
+		if !setting.unset || value == "" {
+			continue
+		}
+		if err := p.set(setting.key, value); err != nil {
+			return fmt.Errorf("invalid %s: %w", setting.env, err)
+		}
+	}
+
+	return nil
+}
+
+// set assigns a setting by its config file key
+func (p *Profile) set(key, value string) error {
+	switch key {
+	case "api_key":
+		p.APIKey = value
+	case "base_url":
+		p.BaseURL = value
+	case "serverless_base_url":
+		p.ServerlessBaseURL = value
+	case "timeout", "retry_delay":
+		d, err := parseConfigDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if key == "timeout" {
+			p.Timeout = d
+		} else {
+			p.RetryDelay = d
+		}
+	case "max_retries":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("max_retries: must be a non-negative integer, got %q", value)
+		}
+		p.MaxRetries = &n
+	case "debug":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("debug: must be true or false, got %q", value)
+		}
+		p.Debug = &b
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+
+	return nil
+}
+
+// parseConfigDuration accepts Go durations such as "45s" or a number of seconds
+func parseConfigDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("cannot be negative")
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("expected a duration such as \"30s\", got %q", value)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("cannot be negative")
+	}
+	return d, nil
+}
+
+// unquoteConfigValue removes TOML string quotes
+func unquoteConfigValue(value string) (string, error) {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1], nil
+	}
+	if strings.HasPrefix(value, "\"") {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid string %s", value)
+		}
+		return unquoted, nil
+	}
+	return value, nil
+}
+
+// stripComment removes a # comment that is not inside a quoted string
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
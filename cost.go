@@ -0,0 +1,266 @@
+package runpod
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CostGroupBy selects how a CostReport aggregates pods
+type CostGroupBy string
+
+const (
+	CostGroupByNamePrefix CostGroupBy = "namePrefix"
+	CostGroupByImage      CostGroupBy = "image"
+	CostGroupByGPUType    CostGroupBy = "gpuType"
+)
+
+// HourlyCost returns what the pod currently costs per hour, preferring the
+// adjusted rate (which includes discounts and spot bids) when RunPod reports one
+func (p *Pod) HourlyCost() float64 {
+	if p.AdjustedCostPerHr > 0 {
+		return p.AdjustedCostPerHr
+	}
+	return p.CostPerHour
+}
+
+// PodCost is the spend of a single pod at a point in time
+type PodCost struct {
+	PodID     string `json:"podId"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	ImageName string `json:"imageName"`
+	GPUTypeID string `json:"gpuTypeId"`
+	Group     string `json:"group"`
+
+	HourlyRate  float64       `json:"hourlyRate"`
+	Uptime      time.Duration `json:"-"`
+	UptimeHours float64       `json:"uptimeHours"`
+
+	// Accrued is the cost since LastStartedAt. Only running pods accrue compute cost.
+	Accrued float64 `json:"accrued"`
+
+	// ProjectedDaily is what the pod costs over 24 hours if it keeps running
+	ProjectedDaily float64 `json:"projectedDaily"`
+}
+
+// CostGroup totals the pods sharing a group key
+type CostGroup struct {
+	Key            string  `json:"key"`
+	Pods           int     `json:"pods"`
+	HourlyRate     float64 `json:"hourlyRate"`
+	Accrued        float64 `json:"accrued"`
+	ProjectedDaily float64 `json:"projectedDaily"`
+}
+
+// CostReport aggregates pod spend
+type CostReport struct {
+	GeneratedAt time.Time   `json:"generatedAt"`
+	GroupBy     CostGroupBy `json:"groupBy"`
+	Pods        []PodCost   `json:"pods"`
+	Groups      []CostGroup `json:"groups"` // Sorted by accrued cost, highest first
+
+	HourlyRate     float64 `json:"hourlyRate"`
+	Accrued        float64 `json:"accrued"`
+	ProjectedDaily float64 `json:"projectedDaily"`
+}
+
+// CostReportOptions configures GetCostReport
+type CostReportOptions struct {
+	// GroupBy selects the aggregation. Defaults to CostGroupByNamePrefix.
+	GroupBy CostGroupBy
+
+	// NamePrefixSeparator splits the name prefix from the rest of a pod name,
+	// so "trainer-7" groups under "trainer". Defaults to "-".
+	NamePrefixSeparator string
+
+	// Filter restricts the report to matching pods
+	Filter *PodFilter
+}
+
+// GetCostReport lists pods and reports their accrued and projected spend
+func (c *Client) GetCostReport(ctx context.Context, opts *CostReportOptions) (*CostReport, error) {
+	if opts == nil {
+		opts = &CostReportOptions{}
+	}
+
+	pods, err := c.ListPodsFiltered(ctx, opts.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods for cost report: %w", err)
+	}
+
+	return NewCostReport(pods, opts, time.Now()), nil
+}
+
+// NewCostReport computes the spend of pods as of now
+func NewCostReport(pods []*Pod, opts *CostReportOptions, now time.Time) *CostReport {
+	if opts == nil {
+		opts = &CostReportOptions{}
+	}
+	groupBy := opts.GroupBy
+	if groupBy == "" {
+		groupBy = CostGroupByNamePrefix
+	}
+	separator := opts.NamePrefixSeparator
+	if separator == "" {
+		separator = "-"
+	}
+
+	report := &CostReport{
+		GeneratedAt: now,
+		GroupBy:     groupBy,
+		Pods:        make([]PodCost, 0, len(pods)),
+	}
+	groups := make(map[string]*CostGroup)
+
+	for _, pod := range pods {
+		cost := ComputePodCost(pod, now)
+		cost.Group = costGroupKey(pod, groupBy, separator)
+		report.Pods = append(report.Pods, cost)
+
+		group, ok := groups[cost.Group]
+		if !ok {
+			group = &CostGroup{Key: cost.Group}
+			groups[cost.Group] = group
+		}
+		group.Pods++
+		group.HourlyRate += cost.HourlyRate
+		group.Accrued += cost.Accrued
+		group.ProjectedDaily += cost.ProjectedDaily
+
+		report.HourlyRate += cost.HourlyRate
+		report.Accrued += cost.Accrued
+		report.ProjectedDaily += cost.ProjectedDaily
+	}
+
+	report.Groups = make([]CostGroup, 0, len(groups))
+	for _, group := range groups {
+		report.Groups = append(report.Groups, *group)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Accrued != report.Groups[j].Accrued {
+			return report.Groups[i].Accrued > report.Groups[j].Accrued
+		}
+		return report.Groups[i].Key < report.Groups[j].Key
+	})
+
+	return report
+}
+
+// ComputePodCost returns the spend of a pod as of now. Pods that are not
+// running have no hourly rate, uptime or accrued compute cost.
+func ComputePodCost(pod *Pod, now time.Time) PodCost {
+	cost := PodCost{
+		PodID:     pod.ID,
+		Name:      pod.Name,
+		Status:    pod.Status(),
+		ImageName: pod.ImageName,
+		GPUTypeID: pod.GPUTypeID(),
+	}
+
+	if !pod.DesiredStatus.IsRunning() {
+		return cost
+	}
+
+	cost.HourlyRate = pod.HourlyCost()
+	cost.ProjectedDaily = cost.HourlyRate * 24
+
+	if pod.LastStartedAt != nil && !pod.LastStartedAt.IsZero() && now.After(pod.LastStartedAt.Time) {
+		cost.Uptime = now.Sub(pod.LastStartedAt.Time)
+		cost.UptimeHours = cost.Uptime.Hours()
+		cost.Accrued = cost.HourlyRate * cost.Uptime.Hours()
+	}
+
+	return cost
+}
+
+// costGroupKey returns the group a pod belongs to
+func costGroupKey(pod *Pod, groupBy CostGroupBy, separator string) string {
+	var key string
+	switch groupBy {
+	case CostGroupByImage:
+		key = pod.ImageName
+	case CostGroupByGPUType:
+		key = pod.GPUTypeID()
+	default:
+		key, _, _ = strings.Cut(pod.Name, separator)
+	}
+
+	if key == "" {
+		return "(none)"
+	}
+	return key
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *CostReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to write cost report: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes one row per pod, with a header
+func (r *CostReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{
+		"pod_id", "name", "status", "image", "gpu_type", "group",
+		"hourly_rate", "uptime_hours", "accrued", "projected_daily",
+	})
+
+	for _, pod := range r.Pods {
+		writer.Write([]string{
+			pod.PodID,
+			pod.Name,
+			pod.Status,
+			pod.ImageName,
+			pod.GPUTypeID,
+			pod.Group,
+			formatCost(pod.HourlyRate),
+			strconv.FormatFloat(pod.UptimeHours, 'f', 2, 64),
+			formatCost(pod.Accrued),
+			formatCost(pod.ProjectedDaily),
+		})
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write cost report: %w", err)
+	}
+	return nil
+}
+
+// WriteGroupsCSV writes one row per group, with a header
+func (r *CostReport) WriteGroupsCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{string(r.GroupBy), "pods", "hourly_rate", "accrued", "projected_daily"})
+
+	for _, group := range r.Groups {
+		writer.Write([]string{
+			group.Key,
+			strconv.Itoa(group.Pods),
+			formatCost(group.HourlyRate),
+			formatCost(group.Accrued),
+			formatCost(group.ProjectedDaily),
+		})
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write cost report: %w", err)
+	}
+	return nil
+}
+
+// formatCost formats a dollar amount with cent-fraction precision
+func formatCost(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 4, 64)
+}
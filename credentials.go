@@ -0,0 +1,133 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialsProvider supplies the API key. It is consulted on every request,
+// so a provider that returns a new key rotates it without restarting.
+// Implementations must be safe for concurrent use.
+type CredentialsProvider interface {
+	APIKey(ctx context.Context) (string, error)
+}
+
+// ErrNoCredentials is returned by providers that have no API key to offer
+var ErrNoCredentials = errors.New("no API key available")
+
+// StaticCredentials is a fixed API key
+type StaticCredentials string
+
+// APIKey returns the key
+func (s StaticCredentials) APIKey(ctx context.Context) (string, error) {
+	if s == "" {
+		return "", ErrNoCredentials
+	}
+	return string(s), nil
+}
+
+// EnvCredentials reads the API key from an environment variable on every
+// request. An empty Name means RUNPOD_API_KEY.
+type EnvCredentials struct {
+	Name string
+}
+
+// APIKey returns the variable's current value
+func (e EnvCredentials) APIKey(ctx context.Context) (string, error) {
+	name := e.Name
+	if name == "" {
+		name = "RUNPOD_API_KEY"
+	}
+
+	key := strings.TrimSpace(os.Getenv(name))
+	if key == "" {
+		return "", fmt.Errorf("%w: %s is not set", ErrNoCredentials, name)
+	}
+	return key, nil
+}
+
+// FileCredentials reads the API key from a file, such as a mounted Kubernetes
+// secret, and reads it again whenever the file's size or modification time
+// changes. Surrounding whitespace is ignored.
+type FileCredentials struct {
+	path string
+
+	mu      sync.Mutex
+	key     string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileCredentials creates a provider for the key stored at path
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{path: path}
+}
+
+// APIKey returns the file's current key
+func (f *FileCredentials) APIKey(ctx context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.key != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.key, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file: %w", err)
+	}
+
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("%w: %s is empty", ErrNoCredentials, f.path)
+	}
+
+	f.key, f.modTime, f.size = key, info.ModTime(), info.Size()
+	return key, nil
+}
+
+// ChainCredentials tries each provider in order and returns the first key found
+type ChainCredentials []CredentialsProvider
+
+// NewChainCredentials creates a provider that falls back through providers in order
+func NewChainCredentials(providers ...CredentialsProvider) ChainCredentials {
+	return ChainCredentials(providers)
+}
+
+// APIKey returns the first key a provider supplies, or an error joining every
+// provider's error
+func (c ChainCredentials) APIKey(ctx context.Context) (string, error) {
+	var errs []error
+	for _, provider := range c {
+		key, err := provider.APIKey(ctx)
+		if err == nil && key != "" {
+			return key, nil
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return "", ErrNoCredentials
+	}
+	return "", errors.Join(errs...)
+}
+
+// WithCredentialsProvider makes the client fetch its API key from provider on
+// every request instead of using the key passed to NewClient, which may then be empty
+func WithCredentialsProvider(provider CredentialsProvider) ClientOption {
+	return func(c *clientConfig) {
+		c.credentials = provider
+	}
+}
@@ -0,0 +1,544 @@
+// Package emulator serves RunPod's serverless /v2 API locally, backed by an
+// in-memory queue that dispatches jobs to a Go handler. Point a client at it
+// with runpod.WithServerlessBaseURL to develop endpoints without deploying:
+//
+//	emu := emulator.New(handler, nil)
+//	defer emu.Close()
+//	go http.ListenAndServe(":8000", emu)
+//
+//	client := runpod.NewClient("local", runpod.WithServerlessBaseURL("http://localhost:8000"))
+//	job, err := client.RunSync(ctx, "my-endpoint", input)
+//
+// ObjectStore is a matching stand-in for S3-compatible storage, for testing
+// runpod.WithOffload.
+package emulator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+	"github.com/cozy-creator/runpod-go-library/worker"
+)
+
+const (
+	// DefaultRunSyncTimeout is how long /runsync waits before returning an unfinished job
+	DefaultRunSyncTimeout = 90 * time.Second
+)
+
+// Options configures an Emulator
+type Options struct {
+	// Workers is how many jobs run concurrently per endpoint. Defaults to 1.
+	Workers int
+
+	// RunSyncTimeout is how long /runsync waits for a job. Defaults to 90 seconds.
+	RunSyncTimeout time.Duration
+
+	// ExecutionTimeout marks jobs TIMED_OUT after this long. Zero means no limit.
+	ExecutionTimeout time.Duration
+
+	// APIKey, if set, is required as a Bearer token on every request
+	APIKey string
+}
+
+// Emulator is an http.Handler serving the serverless job routes
+type Emulator struct {
+	handler worker.Handler
+	opts    Options
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu        sync.Mutex
+	closed    bool
+	nextID    int
+	endpoints map[string]*endpoint
+	jobs      map[string]*jobState
+}
+
+// endpoint holds the queue and workers of a single endpoint ID
+type endpoint struct {
+	id     string
+	queue  []*jobState
+	wakeup chan struct{}
+	busy   int
+}
+
+// jobState tracks a job and the stream chunks not yet read by the client
+type jobState struct {
+	job     runpod.Job
+	pending []interface{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	// attempt changes on retry so a cancelled run cannot overwrite its successor
+	attempt int
+}
+
+// New creates an emulator dispatching every endpoint's jobs to handler
+func New(handler worker.Handler, opts *Options) *Emulator {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.Workers <= 0 {
+		o.Workers = 1
+	}
+	if o.RunSyncTimeout <= 0 {
+		o.RunSyncTimeout = DefaultRunSyncTimeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Emulator{
+		handler:   handler,
+		opts:      o,
+		ctx:       ctx,
+		cancel:    cancel,
+		endpoints: make(map[string]*endpoint),
+		jobs:      make(map[string]*jobState),
+	}
+}
+
+// Close stops all workers, cancels queued and running jobs and waits for the
+// running handlers to return. Later run and retry requests are rejected.
+func (e *Emulator) Close() {
+	e.mu.Lock()
+	e.closed = true
+	for _, ep := range e.endpoints {
+		for _, state := range ep.queue {
+			e.finishLocked(state, runpod.JobStatusCancelled)
+		}
+		ep.queue = nil
+	}
+	e.mu.Unlock()
+
+	e.cancel()
+	e.wg.Wait()
+}
+
+// ServeHTTP routes /v2/{endpoint}/{operation}[/{job}] requests
+func (e *Emulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if e.opts.APIKey != "" && r.Header.Get("Authorization") != "Bearer "+e.opts.APIKey {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || parts[0] != "v2" {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+		return
+	}
+
+	endpointID, operation := parts[1], parts[2]
+	jobID := ""
+	if len(parts) > 3 {
+		jobID = parts[3]
+	}
+
+	switch {
+	case r.Method == "POST" && operation == "run":
+		e.handleRun(w, r, endpointID, false)
+	case r.Method == "POST" && operation == "runsync":
+		e.handleRun(w, r, endpointID, true)
+	case r.Method == "GET" && operation == "status" && jobID != "":
+		e.handleStatus(w, jobID)
+	case r.Method == "GET" && operation == "stream" && jobID != "":
+		e.handleStream(w, jobID)
+	case r.Method == "POST" && operation == "cancel" && jobID != "":
+		e.handleCancel(w, jobID)
+	case r.Method == "POST" && operation == "retry" && jobID != "":
+		e.handleRetry(w, jobID)
+	case r.Method == "POST" && operation == "purge-queue":
+		e.handlePurge(w, endpointID)
+	case r.Method == "GET" && operation == "health":
+		e.handleHealth(w, endpointID)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+	}
+}
+
+// handleRun queues a job, waiting for it to finish when sync is set
+func (e *Emulator) handleRun(w http.ResponseWriter, r *http.Request, endpointID string, sync bool) {
+	var req runpod.RunJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body: " + err.Error()})
+		return
+	}
+	if req.Input == nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "input is required"})
+		return
+	}
+
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "emulator is closed"})
+		return
+	}
+	e.nextID++
+	state := &jobState{
+		job: runpod.Job{
+			ID:         fmt.Sprintf("emu-%d", e.nextID),
+			Status:     string(runpod.JobStatusInQueue),
+			Input:      req.Input,
+			CreatedAt:  &runpod.JSONTime{Time: time.Now()},
+			EndpointID: endpointID,
+		},
+		done: make(chan struct{}),
+	}
+	e.jobs[state.job.ID] = state
+	e.enqueueLocked(endpointID, state)
+	job := state.job
+	e.mu.Unlock()
+
+	if !sync {
+		writeJSON(w, http.StatusOK, job)
+		return
+	}
+
+	select {
+	case <-state.done:
+	case <-time.After(e.opts.RunSyncTimeout):
+	case <-r.Context().Done():
+		return
+	}
+
+	e.mu.Lock()
+	job = state.job
+	e.mu.Unlock()
+	writeJSON(w, http.StatusOK, job)
+}
+
+// handleStatus returns the current state of a job
+func (e *Emulator) handleStatus(w http.ResponseWriter, jobID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, ok := e.jobs[jobID]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "job not found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, state.job)
+}
+
+// handleStream returns the stream chunks produced since the previous call
+func (e *Emulator) handleStream(w http.ResponseWriter, jobID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, ok := e.jobs[jobID]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "job not found"})
+		return
+	}
+
+	stream := make([]map[string]interface{}, 0, len(state.pending))
+	for _, chunk := range state.pending {
+		stream = append(stream, map[string]interface{}{"output": chunk})
+	}
+	state.pending = nil
+
+	job := state.job
+	job.Stream = stream
+	writeJSON(w, http.StatusOK, job)
+}
+
+// handleCancel cancels a queued or running job
+func (e *Emulator) handleCancel(w http.ResponseWriter, jobID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, ok := e.jobs[jobID]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "job not found"})
+		return
+	}
+
+	switch runpod.JobStatus(state.job.Status) {
+	case runpod.JobStatusInQueue:
+		e.removeFromQueueLocked(state)
+		e.finishLocked(state, runpod.JobStatusCancelled)
+	case runpod.JobStatusInProgress:
+		state.cancel()
+		e.finishLocked(state, runpod.JobStatusCancelled)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"id": jobID, "status": state.job.Status})
+}
+
+// handleRetry requeues a failed, cancelled or timed-out job under the same ID
+func (e *Emulator) handleRetry(w http.ResponseWriter, jobID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "emulator is closed"})
+		return
+	}
+
+	state, ok := e.jobs[jobID]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "job not found"})
+		return
+	}
+
+	switch runpod.JobStatus(state.job.Status) {
+	case runpod.JobStatusFailed, runpod.JobStatusCancelled, runpod.JobStatusTimedOut:
+	default:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "only failed, cancelled or timed out jobs can be retried"})
+		return
+	}
+
+	state.job.Status = string(runpod.JobStatusInQueue)
+	state.job.Output = nil
+	state.job.Error = ""
+	state.job.StartedAt = nil
+	state.job.CompletedAt = nil
+	state.job.ExecutionTime = 0
+	state.job.RetryCount++
+	state.attempt++
+	state.pending = nil
+	state.done = make(chan struct{})
+	e.enqueueLocked(state.job.EndpointID, state)
+
+	writeJSON(w, http.StatusOK, state.job)
+}
+
+// handlePurge removes every queued job of an endpoint
+func (e *Emulator) handlePurge(w http.ResponseWriter, endpointID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	removed := 0
+	if ep, ok := e.endpoints[endpointID]; ok {
+		for _, state := range ep.queue {
+			e.finishLocked(state, runpod.JobStatusCancelled)
+			removed++
+		}
+		ep.queue = nil
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"removed": removed, "status": "completed"})
+}
+
+// handleHealth reports queue depth and worker utilisation for an endpoint
+func (e *Emulator) handleHealth(w http.ResponseWriter, endpointID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	health := runpod.EndpointHealth{
+		Status:       "healthy",
+		WorkersIdle:  e.opts.Workers,
+		WorkersTotal: e.opts.Workers,
+	}
+	if ep, ok := e.endpoints[endpointID]; ok {
+		health.JobsInQueue = len(ep.queue)
+		health.WorkersActive = ep.busy
+		health.WorkersIdle = e.opts.Workers - ep.busy
+	}
+
+	writeJSON(w, http.StatusOK, health)
+}
+
+// enqueueLocked adds a job to its endpoint's queue, starting workers on first use.
+// e.mu must be held and the emulator must not be closed.
+func (e *Emulator) enqueueLocked(endpointID string, state *jobState) {
+	ep, ok := e.endpoints[endpointID]
+	if !ok && !e.closed {
+		ep = &endpoint{id: endpointID, wakeup: make(chan struct{}, 1)}
+		e.endpoints[endpointID] = ep
+
+		for i := 0; i < e.opts.Workers; i++ {
+			e.wg.Add(1)
+			go e.work(ep)
+		}
+	}
+
+	ep.queue = append(ep.queue, state)
+	select {
+	case ep.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// removeFromQueueLocked drops a queued job. e.mu must be held.
+func (e *Emulator) removeFromQueueLocked(state *jobState) {
+	ep := e.endpoints[state.job.EndpointID]
+	for i, queued := range ep.queue {
+		if queued == state {
+			ep.queue = append(ep.queue[:i], ep.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// finishLocked moves a job to a terminal status. e.mu must be held.
+func (e *Emulator) finishLocked(state *jobState, status runpod.JobStatus) {
+	if isTerminal(state.job.Status) {
+		return
+	}
+
+	now := time.Now()
+	state.job.Status = string(status)
+	state.job.CompletedAt = &runpod.JSONTime{Time: now}
+	if state.job.StartedAt != nil {
+		state.job.ExecutionTime = int(now.Sub(state.job.StartedAt.Time).Milliseconds())
+	}
+	close(state.done)
+}
+
+// work runs queued jobs of one endpoint until the emulator is closed
+func (e *Emulator) work(ep *endpoint) {
+	defer e.wg.Done()
+
+	for {
+		e.mu.Lock()
+		if e.closed {
+			e.mu.Unlock()
+			return
+		}
+		state, exec := e.dequeueLocked(ep)
+		if len(ep.queue) > 0 {
+			// Let another idle worker pick up the rest
+			select {
+			case ep.wakeup <- struct{}{}:
+			default:
+			}
+		}
+		e.mu.Unlock()
+
+		if state == nil {
+			select {
+			case <-e.ctx.Done():
+				return
+			case <-ep.wakeup:
+				continue
+			}
+		}
+
+		e.run(ep, state, exec)
+	}
+}
+
+// execution is a job as it was when a worker started it
+type execution struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	attempt int
+	job     runpod.Job
+}
+
+// dequeueLocked takes the next job off the queue and marks it in progress, so
+// a cancel or retry arriving before it runs sees it started. e.mu must be held.
+func (e *Emulator) dequeueLocked(ep *endpoint) (*jobState, execution) {
+	if len(ep.queue) == 0 {
+		return nil, execution{}
+	}
+	state := ep.queue[0]
+	ep.queue = ep.queue[1:]
+
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+	if e.opts.ExecutionTimeout > 0 {
+		ctx, cancel = context.WithTimeout(e.ctx, e.opts.ExecutionTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(e.ctx)
+	}
+
+	ep.busy++
+	state.cancel = cancel
+	state.job.Status = string(runpod.JobStatusInProgress)
+	state.job.StartedAt = &runpod.JSONTime{Time: time.Now()}
+	return state, execution{ctx: ctx, cancel: cancel, attempt: state.attempt, job: state.job}
+}
+
+// run executes a single started job through the handler
+func (e *Emulator) run(ep *endpoint, state *jobState, exec execution) {
+	defer exec.cancel()
+
+	ctx, attempt, job := exec.ctx, exec.attempt, exec.job
+
+	em := &emitter{e: e, state: state, attempt: attempt}
+	output, err := e.callHandler(worker.NewJobContext(ctx, &job, em), &job)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ep.busy--
+
+	switch {
+	case state.attempt != attempt || isTerminal(state.job.Status):
+		// Cancelled while running, the handler's result is discarded
+	case ctx.Err() == context.DeadlineExceeded:
+		e.finishLocked(state, runpod.JobStatusTimedOut)
+	case err != nil:
+		state.job.Error = err.Error()
+		e.finishLocked(state, runpod.JobStatusFailed)
+	default:
+		state.job.Output = output
+		e.finishLocked(state, runpod.JobStatusCompleted)
+	}
+}
+
+// callHandler runs the handler, converting a panic into an error
+func (e *Emulator) callHandler(ctx context.Context, job *runpod.Job) (output interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+
+	return e.handler(ctx, job)
+}
+
+// emitter records streamed chunks and progress updates in the job state
+type emitter struct {
+	e       *Emulator
+	state   *jobState
+	attempt int
+}
+
+func (em *emitter) Stream(ctx context.Context, jobID string, chunk interface{}) error {
+	em.e.mu.Lock()
+	defer em.e.mu.Unlock()
+
+	if em.state.attempt == em.attempt && !isTerminal(em.state.job.Status) {
+		em.state.pending = append(em.state.pending, chunk)
+	}
+	return nil
+}
+
+func (em *emitter) Progress(ctx context.Context, jobID string, progress interface{}) error {
+	em.e.mu.Lock()
+	defer em.e.mu.Unlock()
+
+	if em.state.attempt == em.attempt && !isTerminal(em.state.job.Status) {
+		em.state.job.Output = progress
+	}
+	return nil
+}
+
+// isTerminal reports whether a job status is final
+func isTerminal(status string) bool {
+	switch runpod.JobStatus(status) {
+	case runpod.JobStatusCompleted, runpod.JobStatusFailed, runpod.JobStatusCancelled, runpod.JobStatusTimedOut:
+		return true
+	}
+	return false
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,61 @@
+package emulator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+func TestCancelWhileDequeuing(t *testing.T) {
+	handled := make(chan struct{}, 1)
+	e := New(func(ctx context.Context, job *runpod.Job) (interface{}, error) {
+		handled <- struct{}{}
+		return "done", nil
+	}, nil)
+	defer e.Close()
+
+	// Queue the job on an endpoint without workers so the test plays the worker
+	ep := &endpoint{id: "local", wakeup: make(chan struct{}, 1)}
+	state := &jobState{
+		job:  runpod.Job{ID: "emu-1", Status: string(runpod.JobStatusInQueue), EndpointID: "local"},
+		done: make(chan struct{}),
+	}
+	e.endpoints[ep.id] = ep
+	e.jobs[state.job.ID] = state
+	ep.queue = append(ep.queue, state)
+
+	e.mu.Lock()
+	dequeued, exec := e.dequeueLocked(ep)
+	e.mu.Unlock()
+
+	// The cancel arrives after the job left the queue but before it runs
+	rec := httptest.NewRecorder()
+	e.handleCancel(rec, state.job.ID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("cancel status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	e.run(ep, dequeued, exec)
+
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Fatal("handler was not called")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if state.job.Status != string(runpod.JobStatusCancelled) {
+		t.Errorf("job status = %v, want CANCELLED", state.job.Status)
+	}
+	if state.job.Output != nil {
+		t.Errorf("job output = %v, want none", state.job.Output)
+	}
+	if ep.busy != 0 {
+		t.Errorf("busy workers = %d, want 0", ep.busy)
+	}
+}
@@ -0,0 +1,161 @@
+package emulator
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ObjectStore is an in-memory stand-in for an S3-compatible service, for
+// developing and testing job offloading without a real bucket. Point a
+// runpod.S3Store at it with path-style addressing:
+//
+//	store := emulator.NewObjectStore("AKIDEXAMPLE")
+//	server := httptest.NewServer(store)
+//
+//	s3, err := runpod.NewS3Store(&runpod.S3StoreOptions{
+//		Endpoint: server.URL, PathStyle: true, Bucket: "jobs",
+//		AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret",
+//	})
+//
+// It checks that requests are signed, or presigned and unexpired, with the
+// access key ID, but does not verify signatures.
+type ObjectStore struct {
+	accessKeyID string
+
+	mu      sync.Mutex
+	objects map[string]storedObject
+}
+
+// storedObject is an object and its content type
+type storedObject struct {
+	data        []byte
+	contentType string
+}
+
+// NewObjectStore creates an empty store accepting requests signed with accessKeyID
+func NewObjectStore(accessKeyID string) *ObjectStore {
+	return &ObjectStore{
+		accessKeyID: accessKeyID,
+		objects:     make(map[string]storedObject),
+	}
+}
+
+// PutObject stores an object directly, as a worker uploading its output would
+func (s *ObjectStore) PutObject(bucket, key string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[bucket+"/"+key] = storedObject{data: data, contentType: http.DetectContentType(data)}
+}
+
+// Object returns a stored object
+func (s *ObjectStore) Object(bucket, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[bucket+"/"+key]
+	return obj.data, ok
+}
+
+// Keys returns the keys of every object in bucket
+func (s *ObjectStore) Keys(bucket string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var keys []string
+	for name := range s.objects {
+		if key, ok := strings.CutPrefix(name, bucket+"/"); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// ServeHTTP handles path-style PUT and GET object requests: /{bucket}/{key}
+func (s *ObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	if bucket, key, _ := strings.Cut(name, "/"); bucket == "" || key == "" {
+		writeS3Error(w, http.StatusBadRequest, "InvalidRequest", "path-style /bucket/key required")
+		return
+	}
+
+	if code, message := s.authorize(r); code != "" {
+		writeS3Error(w, http.StatusForbidden, code, message)
+		return
+	}
+
+	switch r.Method {
+	case "PUT":
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeS3Error(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		s.objects[name] = storedObject{data: data, contentType: r.Header.Get("Content-Type")}
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+
+	case "GET":
+		s.mu.Lock()
+		obj, ok := s.objects[name]
+		s.mu.Unlock()
+
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+			return
+		}
+		if obj.contentType != "" {
+			w.Header().Set("Content-Type", obj.contentType)
+		}
+		w.Write(obj.data)
+
+	default:
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "only PUT and GET are supported")
+	}
+}
+
+// authorize checks a request's signature header or presigned query
+func (s *ObjectStore) authorize(r *http.Request) (code, message string) {
+	credential := "Credential=" + s.accessKeyID + "/"
+
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 ") || !strings.Contains(auth, credential) {
+			return "InvalidAccessKeyId", "unknown access key or signature scheme"
+		}
+		return "", ""
+	}
+
+	query := r.URL.Query()
+	if !strings.HasPrefix(query.Get("X-Amz-Credential"), s.accessKeyID+"/") || query.Get("X-Amz-Signature") == "" {
+		return "AccessDenied", "request is not signed"
+	}
+
+	signedAt, err := time.Parse("20060102T150405Z", query.Get("X-Amz-Date"))
+	expires, err2 := time.ParseDuration(query.Get("X-Amz-Expires") + "s")
+	if err != nil || err2 != nil {
+		return "AuthorizationQueryParametersError", "invalid X-Amz-Date or X-Amz-Expires"
+	}
+	if time.Now().After(signedAt.Add(expires)) {
+		return "AccessDenied", "Request has expired"
+	}
+
+	return "", ""
+}
+
+// writeS3Error writes an S3-style XML error
+func writeS3Error(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	io.WriteString(w, xml.Header)
+	xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{Code: code, Message: message})
+}
@@ -0,0 +1,59 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetEndpoint retrieves a serverless endpoint by ID
+func (c *Client) GetEndpoint(ctx context.Context, endpointID string) (*Endpoint, error) {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return nil, err
+	}
+
+	var endpoint Endpoint
+	err := c.Get(ctx, fmt.Sprintf("/endpoints/%s", endpointID), &endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint %s: %w", endpointID, err)
+	}
+
+	return &endpoint, nil
+}
+
+// UpdateEndpoint changes an endpoint's settings. Fields left empty are not
+// changed; set req.SetWorkers to send zero worker counts.
+func (c *Client) UpdateEndpoint(ctx context.Context, endpointID string, req *UpdateEndpointRequest) (*Endpoint, error) {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return nil, err
+	}
+	if err := c.validateUpdateEndpointRequest(req); err != nil {
+		return nil, err
+	}
+
+	var endpoint Endpoint
+	err := c.Patch(ctx, fmt.Sprintf("/endpoints/%s", endpointID), req, &endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update endpoint %s: %w", endpointID, err)
+	}
+
+	return &endpoint, nil
+}
+
+// validateUpdateEndpointRequest validates an endpoint update request
+func (c *Client) validateUpdateEndpointRequest(req *UpdateEndpointRequest) error {
+	if req == nil {
+		return NewValidationError("request", "cannot be nil")
+	}
+
+	if req.WorkersMin < 0 {
+		return NewValidationErrorWithValue("workersMin", "cannot be negative", req.WorkersMin)
+	}
+	if req.WorkersMax < 0 {
+		return NewValidationErrorWithValue("workersMax", "cannot be negative", req.WorkersMax)
+	}
+	if (req.SetWorkers || req.WorkersMax > 0) && req.WorkersMin > req.WorkersMax {
+		return NewValidationErrorWithValue("workersMin", "cannot exceed workersMax", req.WorkersMin)
+	}
+
+	return nil
+}
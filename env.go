@@ -0,0 +1,117 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// secretRefPattern matches RunPod's secret reference syntax, {{ RUNPOD_SECRET_name }}
+var secretRefPattern = regexp.MustCompile(`\{\{\s*RUNPOD_SECRET_([A-Za-z0-9_.\-]+)\s*\}\}`)
+
+// SecretRef renders a reference to a RunPod secret for use as an env value.
+// RunPod substitutes the secret's value when the pod starts.
+func SecretRef(name string) string {
+	return "{{ RUNPOD_SECRET_" + name + " }}"
+}
+
+// ParseSecretRef returns the secret name if value is exactly a secret reference
+func ParseSecretRef(value string) (string, bool) {
+	match := secretRefPattern.FindStringSubmatch(value)
+	if match == nil || match[0] != value {
+		return "", false
+	}
+	return match[1], true
+}
+
+// SecretRefs returns the sorted, de-duplicated names of all secrets referenced in env
+func SecretRefs(env map[string]string) []string {
+	seen := make(map[string]bool)
+	var names []string
+
+	for _, value := range env {
+		for _, match := range secretRefPattern.FindAllStringSubmatch(value, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				names = append(names, match[1])
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// EnvBuilder builds a pod or template env map mixing literal values and secret references
+type EnvBuilder struct {
+	env  map[string]string
+	errs ValidationErrors
+}
+
+// NewEnvBuilder creates an empty env builder
+func NewEnvBuilder() *EnvBuilder {
+	return &EnvBuilder{env: make(map[string]string)}
+}
+
+// Set adds a literal value
+func (b *EnvBuilder) Set(key, value string) *EnvBuilder {
+	if key == "" {
+		b.errs = append(b.errs, *NewValidationError("env", "key cannot be empty"))
+		return b
+	}
+	b.env[key] = value
+	return b
+}
+
+// SetSecret adds a reference to the named RunPod secret
+func (b *EnvBuilder) SetSecret(key, secretName string) *EnvBuilder {
+	if secretName == "" {
+		b.errs = append(b.errs, *NewValidationErrorWithValue("env", "secret name cannot be empty", key))
+		return b
+	}
+	return b.Set(key, SecretRef(secretName))
+}
+
+// Merge adds every entry of env, overriding existing keys
+func (b *EnvBuilder) Merge(env map[string]string) *EnvBuilder {
+	for key, value := range env {
+		b.Set(key, value)
+	}
+	return b
+}
+
+// SecretNames returns the names of all secrets referenced so far
+func (b *EnvBuilder) SecretNames() []string {
+	return SecretRefs(b.env)
+}
+
+// Build returns a copy of the env map, or the errors recorded while building it
+func (b *EnvBuilder) Build() (map[string]string, error) {
+	if len(b.errs) > 0 {
+		return nil, b.errs
+	}
+
+	env := make(map[string]string, len(b.env))
+	for key, value := range b.env {
+		env[key] = value
+	}
+	return env, nil
+}
+
+// ValidateEnvSecrets checks that every secret referenced in env exists
+func (c *Client) ValidateEnvSecrets(ctx context.Context, env map[string]string) error {
+	for _, name := range SecretRefs(env) {
+		_, err := c.GetSecret(ctx, name)
+		if err == nil {
+			continue
+		}
+
+		if isNotFoundError(err) {
+			return NewValidationErrorWithValue("env", "references a secret that does not exist", name)
+		}
+		return fmt.Errorf("failed to validate secret reference %s: %w", name, err)
+	}
+
+	return nil
+}
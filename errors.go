@@ -1,6 +1,10 @@
 package runpod
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 type APIError struct {
 	StatusCode int    `json:"statusCode"`
@@ -45,6 +49,31 @@ func (e *APIError) IsClientError() bool {
 	return e.StatusCode >= 400 && e.StatusCode < 500
 }
 
+// noCapacityMessages are fragments of the messages RunPod returns when no
+// machine can host the requested GPU type, datacenter or cloud type
+var noCapacityMessages = []string{
+	"no longer any instances available",
+	"no instances available",
+	"not enough free gpus",
+	"no available machines",
+	"does not have the resources to deploy",
+	"no gpus available",
+	"insufficient capacity",
+}
+
+// IsNoCapacity reports whether the error means the requested resources are
+// currently sold out, as opposed to the request itself being invalid
+func (e *APIError) IsNoCapacity() bool {
+	text := strings.ToLower(e.Message + " " + e.Details)
+	for _, fragment := range noCapacityMessages {
+		if strings.Contains(text, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type ValidationError struct {
 	Field   string      `json:"field"`
 	Message string      `json:"message"`
@@ -118,6 +147,49 @@ func (e *RateLimitError) Error() string {
 	return fmt.Sprintf("rate limit exceeded: %s (retry after: %s)", e.Message, e.RetryAfter)
 }
 
+// GraphQLError represents the errors array of a GraphQL response
+type GraphQLError struct {
+	Errors []GraphQLErrorDetail
+
+	// StatusCode is the HTTP status of the response when it was 400 or above,
+	// otherwise 0. Such errors also unwrap to an *APIError with this status.
+	StatusCode int
+}
+
+// GraphQLErrorDetail is a single entry of a GraphQL errors array
+type GraphQLErrorDetail struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Error implements the error interface
+func (e *GraphQLError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, detail := range e.Errors {
+		messages = append(messages, detail.Message)
+	}
+	return fmt.Sprintf("GraphQL error: %s", strings.Join(messages, "; "))
+}
+
+// Unwrap returns an *APIError for errors delivered with an HTTP error status
+func (e *GraphQLError) Unwrap() error {
+	if e.StatusCode < 400 {
+		return nil
+	}
+	return NewAPIError(e.StatusCode, e.Error())
+}
+
+// Code returns the extensions.code of the first error, if any
+func (e *GraphQLError) Code() string {
+	for _, detail := range e.Errors {
+		if code, ok := detail.Extensions["code"].(string); ok {
+			return code
+		}
+	}
+	return ""
+}
+
 // NewAPIError creates a new API error
 func NewAPIError(statusCode int, message string) *APIError {
 	return &APIError{
@@ -216,3 +288,25 @@ func IsRateLimitError(err error) bool {
 	_, ok := err.(*RateLimitError)
 	return ok
 }
+
+// IsGraphQLError checks if an error, or any error it wraps, is a GraphQLError
+func IsGraphQLError(err error) bool {
+	var gqlErr *GraphQLError
+	return errors.As(err, &gqlErr)
+}
+
+// IsNoCapacityError checks if an error, or any error it wraps, is an APIError
+// reporting that no capacity is available for the requested resources
+func IsNoCapacityError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IsNoCapacity()
+	}
+	return false
+}
+
+// isNotFoundError checks if an error, or any error it wraps, is a 404 APIError
+func isNotFoundError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsNotFound()
+}
@@ -0,0 +1,240 @@
+package runpod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GraphQLRequest is the body of a GraphQL request
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+}
+
+// graphQLResponse is the envelope of a GraphQL response
+type graphQLResponse struct {
+	Data   json.RawMessage      `json:"data"`
+	Errors []GraphQLErrorDetail `json:"errors,omitempty"`
+}
+
+// GraphQL runs a query or mutation against the RunPod GraphQL API and decodes
+// its data field into result. It uses the same authentication, retries and
+// error types as REST requests; errors reported in the response body are
+// returned as a *GraphQLError, or an *AuthError for unauthenticated requests.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	if err := c.validateRequired("query", query); err != nil {
+		return err
+	}
+
+	resp, err := c.makeRequest(ctx, "POST", c.GetGraphQLURL(), &GraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	cfg := c.snapshot()
+	if cfg.debug {
+		cfg.logger.Printf("[DEBUG] Response Status: %d", resp.StatusCode)
+	}
+
+	body, err := cfg.readBody(resp)
+	if err != nil {
+		return err
+	}
+	cfg.logResponseBody(body)
+
+	// GraphQL servers report request errors in the errors array even on HTTP failures
+	var envelope graphQLResponse
+	decodeErr := json.Unmarshal(body, &envelope)
+
+	if decodeErr == nil && len(envelope.Errors) > 0 {
+		gqlErr := &GraphQLError{Errors: envelope.Errors}
+		if resp.StatusCode >= 400 {
+			gqlErr.StatusCode = resp.StatusCode
+		}
+		if gqlErr.Code() == "UNAUTHENTICATED" {
+			return NewAuthError(envelope.Errors[0].Message)
+		}
+		return gqlErr
+	}
+
+	if resp.StatusCode >= 400 {
+		return c.parseErrorResponse(resp.StatusCode, body)
+	}
+
+	if decodeErr != nil {
+		return fmt.Errorf("failed to unmarshal GraphQL response: %w", decodeErr)
+	}
+
+	if result != nil && len(envelope.Data) > 0 && string(envelope.Data) != "null" {
+		if err := json.Unmarshal(envelope.Data, result); err != nil {
+			return fmt.Errorf("failed to unmarshal GraphQL data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ================================
+// GPU AVAILABILITY
+// ================================
+
+// GPUAvailabilityOptions narrows a GetGPUAvailability query
+type GPUAvailabilityOptions struct {
+	// GPUTypeID limits the result to one GPU type
+	GPUTypeID string
+
+	// GPUCount is the number of GPUs per pod that prices and stock are quoted for. Defaults to 1.
+	GPUCount int
+
+	// SecureCloud quotes secure cloud (true) or community cloud (false) only. Nil quotes both.
+	SecureCloud *bool
+
+	// DataCenterID quotes a single datacenter
+	DataCenterID string
+}
+
+const gpuAvailabilityQuery = `query GPUAvailability($filter: GpuTypeFilter, $lowestPrice: GpuLowestPriceInput) {
+  gpuTypes(input: $filter) {
+    id
+    displayName
+    memoryInGb
+    secureCloud
+    communityCloud
+    securePrice
+    communityPrice
+    secureSpotPrice
+    communitySpotPrice
+    lowestPrice(input: $lowestPrice) {
+      minimumBidPrice
+      uninterruptablePrice
+      stockStatus
+      maxUnreservedGpuCount
+      availableGpuCounts
+    }
+  }
+}`
+
+// GetGPUAvailability lists GPU types with their current stock, spot prices and
+// minimum bids, which the REST API does not expose. Available is set on types
+// that are currently in stock.
+func (c *Client) GetGPUAvailability(ctx context.Context, opts *GPUAvailabilityOptions) ([]*GPUType, error) {
+	if opts == nil {
+		opts = &GPUAvailabilityOptions{}
+	}
+
+	gpuCount := opts.GPUCount
+	if gpuCount <= 0 {
+		gpuCount = 1
+	}
+
+	lowestPrice := map[string]interface{}{"gpuCount": gpuCount}
+	if opts.SecureCloud != nil {
+		lowestPrice["secureCloud"] = *opts.SecureCloud
+	}
+	if opts.DataCenterID != "" {
+		lowestPrice["dataCenterId"] = opts.DataCenterID
+	}
+
+	variables := map[string]interface{}{"lowestPrice": lowestPrice}
+	if opts.GPUTypeID != "" {
+		variables["filter"] = map[string]interface{}{"id": opts.GPUTypeID}
+	}
+
+	var data struct {
+		GPUTypes []*GPUType `json:"gpuTypes"`
+	}
+	if err := c.GraphQL(ctx, gpuAvailabilityQuery, variables, &data); err != nil {
+		return nil, fmt.Errorf("failed to get gpu availability: %w", err)
+	}
+
+	for _, gpuType := range data.GPUTypes {
+		gpuType.Available = gpuType.LowestPrice != nil && gpuType.LowestPrice.StockStatus != ""
+	}
+
+	return data.GPUTypes, nil
+}
+
+// ================================
+// SPOT BIDS
+// ================================
+
+const podBidResumeMutation = `mutation ResumeSpotPod($input: PodBidResumeInput!) {
+  podBidResume(input: $input) {
+    id
+    name
+    desiredStatus
+    image: imageName
+    gpuCount
+    costPerHr
+    machineId
+  }
+}`
+
+// ResumeSpotPod resumes a stopped spot pod with a new bid of bidPerGPU $/hr per
+// GPU. The REST API can only resume pods at their original bid, so this is the
+// way back for a pod that was outbid.
+func (c *Client) ResumeSpotPod(ctx context.Context, podID string, bidPerGPU float64, gpuCount int) (*Pod, error) {
+	if err := c.validateRequired("podID", podID); err != nil {
+		return nil, err
+	}
+	if err := c.validatePositiveFloat("bidPerGpu", bidPerGPU); err != nil {
+		return nil, err
+	}
+	if err := c.validatePositive("gpuCount", gpuCount); err != nil {
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"podId":     podID,
+			"bidPerGpu": bidPerGPU,
+			"gpuCount":  gpuCount,
+		},
+	}
+
+	var data struct {
+		PodBidResume *Pod `json:"podBidResume"`
+	}
+	if err := c.GraphQL(ctx, podBidResumeMutation, variables, &data); err != nil {
+		return nil, fmt.Errorf("failed to resume spot pod %s: %w", podID, err)
+	}
+	if data.PodBidResume == nil {
+		return nil, fmt.Errorf("failed to resume spot pod %s: no pod returned", podID)
+	}
+
+	return data.PodBidResume, nil
+}
+
+// ================================
+// BILLING
+// ================================
+
+const accountInfoQuery = `query AccountInfo {
+  myself {
+    id
+    email
+    balance: clientBalance
+    spendLimit
+    currentSpendPerHr
+    machineQuota
+  }
+}`
+
+// GetAccountInfo retrieves the account's balance, spend limit and current
+// spend rate
+func (c *Client) GetAccountInfo(ctx context.Context) (*AccountInfo, error) {
+	var data struct {
+		Myself *AccountInfo `json:"myself"`
+	}
+	if err := c.GraphQL(ctx, accountInfoQuery, nil, &data); err != nil {
+		return nil, fmt.Errorf("failed to get account info: %w", err)
+	}
+	if data.Myself == nil {
+		return nil, fmt.Errorf("failed to get account info: no account returned")
+	}
+
+	return data.Myself, nil
+}
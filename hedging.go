@@ -0,0 +1,156 @@
+package runpod
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// HedgeStats counts hedged requests since the client was created
+type HedgeStats struct {
+	// Requests is the number of hedgeable requests made
+	Requests int64
+
+	// Hedges is the number of those that sent a second request because the
+	// first had not answered within the hedge delay
+	Hedges int64
+
+	// HedgeWins is the number of hedges whose second request answered first
+	HedgeWins int64
+}
+
+// hedgeCounters holds the live HedgeStats counters, shared by clients derived
+// with Clone or With
+type hedgeCounters struct {
+	requests  atomic.Int64
+	hedges    atomic.Int64
+	hedgeWins atomic.Int64
+}
+
+// WithHedging enables request hedging for idempotent reads: GetJobStatus,
+// GetHealth and GetPod. If a request has not answered after delay, a second
+// identical request is sent and the first successful response wins; the other
+// request is cancelled. Zero disables hedging.
+func WithHedging(delay time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.setHedging(delay)
+	}
+}
+
+// WithRequestHedging enables request hedging for a derived client (see WithHedging)
+func WithRequestHedging(delay time.Duration) RequestOption {
+	return func(c *clientConfig) {
+		c.setHedging(delay)
+	}
+}
+
+// setHedging sets the hedge delay, creating the counters on first use
+func (cfg *clientConfig) setHedging(delay time.Duration) {
+	cfg.hedgeDelay = delay
+	if cfg.hedgeCounters == nil {
+		cfg.hedgeCounters = &hedgeCounters{}
+	}
+}
+
+// GetHedgeStats returns how often requests were hedged and how often the hedge won
+func (c *Client) GetHedgeStats() HedgeStats {
+	counters := c.snapshot().hedgeCounters
+	if counters == nil {
+		return HedgeStats{}
+	}
+
+	return HedgeStats{
+		Requests:  counters.requests.Load(),
+		Hedges:    counters.hedges.Load(),
+		HedgeWins: counters.hedgeWins.Load(),
+	}
+}
+
+// hedgedGet performs a GET request, hedging it if enabled. It must only be
+// used for idempotent requests. The first successful response wins and its
+// body is decoded into result by handleResponse; the other request is cancelled.
+func (c *Client) hedgedGet(ctx context.Context, endpoint string, result interface{}) error {
+	cfg := c.snapshot()
+	if cfg.hedgeDelay <= 0 {
+		return c.Get(ctx, endpoint, result)
+	}
+	cfg.hedgeCounters.requests.Add(1)
+
+	type attempt struct {
+		index int
+		resp  *http.Response
+		err   error
+	}
+	results := make(chan attempt, 2)
+	var cancels []context.CancelFunc
+	send := func() {
+		attemptCtx, cancel := context.WithCancel(ctx)
+		index := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			resp, err := c.makeRequest(attemptCtx, "GET", endpoint, nil)
+			results <- attempt{index: index, resp: resp, err: err}
+		}()
+	}
+
+	pending := 0
+	defer func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+		// Close the bodies of requests that answer after the winner
+		go func(pending int) {
+			for ; pending > 0; pending-- {
+				if res := <-results; res.resp != nil {
+					res.resp.Body.Close()
+				}
+			}
+		}(pending)
+	}()
+
+	send()
+	pending++
+
+	timer := time.NewTimer(cfg.hedgeDelay)
+	defer timer.Stop()
+
+	var firstErr error
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			cfg.hedgeCounters.hedges.Add(1)
+			if cfg.debug {
+				cfg.logger.Printf("[DEBUG] No response to GET %s after %s, sending hedge request", endpoint, cfg.hedgeDelay)
+			}
+			send()
+			pending++
+
+		case res := <-results:
+			pending--
+			err := res.err
+			if err == nil && res.resp.StatusCode >= 400 {
+				err = c.handleResponse(res.resp, nil)
+			}
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+
+			// Cancel the other request now rather than after decoding
+			for i, cancel := range cancels {
+				if i != res.index {
+					cancel()
+				}
+			}
+			if res.index > 0 {
+				cfg.hedgeCounters.hedgeWins.Add(1)
+			}
+			return c.handleResponse(res.resp, result)
+		}
+	}
+
+	return firstErr
+}
@@ -2,6 +2,7 @@ package runpod
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"time"
@@ -18,12 +19,16 @@ func (c *Client) RunAsync(ctx context.Context, endpointID string, input interfac
 		return nil, err
 	}
 
+	input, err := c.offloadInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
 	req := &RunJobRequest{Input: input}
 
 	endpoint := fmt.Sprintf("/v2/%s/run", endpointID)
 
 	var job Job
-	err := c.Post(ctx, endpoint, req, &job)
+	err = c.Post(ctx, endpoint, req, &job)
 	if err != nil {
 		return nil, fmt.Errorf("failed to submit async job to endpoint %s: %w", endpointID, err)
 	}
@@ -38,16 +43,24 @@ func (c *Client) RunSync(ctx context.Context, endpointID string, input interface
 		return nil, err
 	}
 
+	input, err := c.offloadInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
 	req := &RunJobRequest{Input: input}
 
 	endpoint := fmt.Sprintf("/v2/%s/runsync", endpointID)
 
 	var job Job
-	err := c.Post(ctx, endpoint, req, &job)
+	err = c.Post(ctx, endpoint, req, &job)
 	if err != nil {
 		return nil, fmt.Errorf("failed to submit sync job to endpoint %s: %w", endpointID, err)
 	}
 
+	if err := c.resolveOutput(ctx, &job); err != nil {
+		return nil, err
+	}
+
 	return &job, nil
 }
 
@@ -63,11 +76,15 @@ func (c *Client) GetJobStatus(ctx context.Context, endpointID, jobID string) (*J
 	endpoint := fmt.Sprintf("/v2/%s/status/%s", endpointID, jobID)
 
 	var job Job
-	err := c.Get(ctx, endpoint, &job)
+	err := c.hedgedGet(ctx, endpoint, &job)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get status for job %s on endpoint %s: %w", jobID, endpointID, err)
 	}
 
+	if err := c.resolveOutput(ctx, &job); err != nil {
+		return nil, err
+	}
+
 	return &job, nil
 }
 
@@ -136,7 +153,7 @@ func (c *Client) GetHealth(ctx context.Context, endpointID string) (*EndpointHea
 	endpoint := fmt.Sprintf("/v2/%s/health", endpointID)
 
 	var health EndpointHealth
-	err := c.Get(ctx, endpoint, &health)
+	err := c.hedgedGet(ctx, endpoint, &health)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get health for endpoint %s: %w", endpointID, err)
 	}
@@ -319,9 +336,87 @@ func (c *Client) StreamResults(ctx context.Context, endpointID, jobID string) (*
 		return nil, fmt.Errorf("failed to stream results for job %s on endpoint %s: %w", jobID, endpointID, err)
 	}
 
+	if err := c.resolveOutput(ctx, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// RunSyncRaw submits a job like RunSync, leaving its output as raw JSON
+func (c *Client) RunSyncRaw(ctx context.Context, endpointID string, input interface{}) (*RawJob, error) {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/v2/%s/runsync", endpointID)
+
+	input, err := c.offloadInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var job RawJob
+	err = c.Post(ctx, endpoint, &RunJobRequest{Input: input}, &job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit sync job to endpoint %s: %w", endpointID, err)
+	}
+
 	return &job, nil
 }
 
+// GetJobStatusRaw retrieves a job like GetJobStatus, leaving its output as raw JSON
+func (c *Client) GetJobStatusRaw(ctx context.Context, endpointID, jobID string) (*RawJob, error) {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return nil, err
+	}
+	if err := c.validateRequired("jobID", jobID); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/v2/%s/status/%s", endpointID, jobID)
+
+	var job RawJob
+	err := c.hedgedGet(ctx, endpoint, &job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status for job %s on endpoint %s: %w", jobID, endpointID, err)
+	}
+
+	return &job, nil
+}
+
+// StreamResultsRaw retrieves streaming results like StreamResults, leaving
+// the output and stream as raw JSON
+func (c *Client) StreamResultsRaw(ctx context.Context, endpointID, jobID string) (*RawJob, error) {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return nil, err
+	}
+	if err := c.validateRequired("jobID", jobID); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/v2/%s/stream/%s", endpointID, jobID)
+
+	var job RawJob
+	err := c.Get(ctx, endpoint, &job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream results for job %s on endpoint %s: %w", jobID, endpointID, err)
+	}
+
+	return &job, nil
+}
+
+// DecodeOutput unmarshals the job's output into v
+func (j *RawJob) DecodeOutput(v interface{}) error {
+	if len(j.Output) == 0 {
+		return fmt.Errorf("job %s has no output", j.ID)
+	}
+	if err := json.Unmarshal(j.Output, v); err != nil {
+		return fmt.Errorf("failed to decode output of job %s: %w", j.ID, err)
+	}
+	return nil
+}
+
 // StreamResultsContinuous polls the stream endpoint for continuous updates
 // Returns channels for job updates and errors - useful for long-running jobs
 // This provides a convenient wrapper around StreamResults for real-time monitoring
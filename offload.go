@@ -0,0 +1,259 @@
+package runpod
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultOffloadThreshold is the input field size above which fields are uploaded
+	DefaultOffloadThreshold = 256 << 10
+
+	// DefaultOffloadURLExpiry is how long presigned input URLs stay valid
+	DefaultOffloadURLExpiry = time.Hour
+)
+
+// ObjectStore stores job inputs and outputs too large to send through the
+// RunPod API. S3Store implements it for S3-compatible services.
+type ObjectStore interface {
+	// Put uploads an object
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+
+	// Get downloads an object
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// PresignGet returns a URL workers can download the object from without credentials
+	PresignGet(ctx context.Context, key string, expires time.Duration) (string, error)
+
+	// KeyFromURL reports whether a URL points at an object in the store, and its key
+	KeyFromURL(rawURL string) (key string, ok bool)
+}
+
+// OffloadOptions configures offloading large job inputs and outputs to an ObjectStore
+type OffloadOptions struct {
+	// Store holds the offloaded data. Required.
+	Store ObjectStore
+
+	// Threshold is the size in bytes above which string input fields are
+	// uploaded. Defaults to DefaultOffloadThreshold.
+	Threshold int
+
+	// KeyPrefix is prepended to the keys of uploaded inputs. Defaults to "runpod-inputs/".
+	KeyPrefix string
+
+	// URLExpiry is how long the presigned input URLs stay valid. It must cover
+	// the time jobs wait in the queue. Defaults to DefaultOffloadURLExpiry.
+	URLExpiry time.Duration
+
+	// KeepOutputURLs leaves output URLs pointing at the store as they are
+	// instead of downloading them. Downloaded objects replace their URL with
+	// the object's text, or with a base64 data URI when it is not text.
+	KeepOutputURLs bool
+}
+
+// WithOffload offloads large job inputs to an object store. RunAsync, RunSync
+// and RunSyncRaw upload every input string field larger than the threshold and
+// send a presigned URL in its place; data URI fields are uploaded decoded and
+// all other strings verbatim. RunSync, GetJobStatus (and so
+// WaitForJobCompletion and RunAndWait), StreamResults and
+// StreamResultsContinuous replace output and stream strings that are URLs of
+// objects in the store with the object's contents: text as is, anything else
+// as a base64 data URI. Output is only downloaded once the job has finished,
+// so polling an unfinished job does not fetch its progress again and again.
+// The Raw variants leave job outputs untouched.
+func WithOffload(opts *OffloadOptions) ClientOption {
+	return func(c *clientConfig) {
+		if opts == nil || opts.Store == nil {
+			c.offload = nil
+			return
+		}
+
+		o := *opts
+		if o.Threshold <= 0 {
+			o.Threshold = DefaultOffloadThreshold
+		}
+		if o.KeyPrefix == "" {
+			o.KeyPrefix = "runpod-inputs/"
+		}
+		if o.URLExpiry <= 0 {
+			o.URLExpiry = DefaultOffloadURLExpiry
+		}
+		c.offload = &o
+	}
+}
+
+// offloadInput uploads oversized input fields, returning the input to send
+func (c *Client) offloadInput(ctx context.Context, input interface{}) (interface{}, error) {
+	cfg := c.snapshot()
+	if cfg.offload == nil || input == nil {
+		return input, nil
+	}
+
+	// Work on the JSON form so any input type is handled the same way
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input: %w", err)
+	}
+	if len(data) <= cfg.offload.Threshold {
+		return input, nil
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	uploads := 0
+	generic, err = walkStrings(generic, "input", func(path, value string) (interface{}, error) {
+		if len(value) <= cfg.offload.Threshold {
+			return value, nil
+		}
+
+		url, err := cfg.offload.upload(ctx, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to offload %s: %w", path, err)
+		}
+		uploads++
+		if cfg.debug {
+			cfg.logger.Printf("[DEBUG] Offloaded %s (%d bytes) to object storage", path, len(value))
+		}
+		return url, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if uploads == 0 {
+		return input, nil
+	}
+	return generic, nil
+}
+
+// resolveOutput replaces output and stream URLs of objects in the store with
+// their contents. The output of unfinished jobs is left alone; stream chunks
+// are delivered once each, so they are resolved as they arrive.
+func (c *Client) resolveOutput(ctx context.Context, job *Job) error {
+	cfg := c.snapshot()
+	if cfg.offload == nil || cfg.offload.KeepOutputURLs || job == nil {
+		return nil
+	}
+
+	download := func(path, value string) (interface{}, error) {
+		key, ok := cfg.offload.Store.KeyFromURL(value)
+		if !ok {
+			return value, nil
+		}
+
+		data, err := cfg.offload.Store.Get(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download %s of job %s: %w", path, job.ID, err)
+		}
+		return encodeOutputValue(data), nil
+	}
+
+	if job.Output != nil && c.IsJobTerminal(job.Status) {
+		output, err := walkStrings(job.Output, "output", download)
+		if err != nil {
+			return err
+		}
+		job.Output = output
+	}
+
+	if job.Stream != nil {
+		stream, err := walkStrings(job.Stream, "stream", download)
+		if err != nil {
+			return err
+		}
+		job.Stream = stream
+	}
+
+	return nil
+}
+
+// upload stores an input value and returns a presigned URL for it
+func (o *OffloadOptions) upload(ctx context.Context, value string) (string, error) {
+	data, contentType := decodeInputValue(value)
+
+	suffix := make([]byte, 16)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate object key: %w", err)
+	}
+	key := o.KeyPrefix + hex.EncodeToString(suffix)
+
+	if err := o.Store.Put(ctx, key, data, contentType); err != nil {
+		return "", err
+	}
+	return o.Store.PresignGet(ctx, key, o.URLExpiry)
+}
+
+// decodeInputValue returns the bytes an input string stands for: the payload of
+// a base64 data URI, or otherwise the text itself. Plain strings are never
+// decoded, since long tokens can look like base64 by chance.
+func decodeInputValue(value string) ([]byte, string) {
+	if rest, ok := strings.CutPrefix(value, "data:"); ok {
+		if meta, payload, ok := strings.Cut(rest, ","); ok {
+			if mediaType, ok := strings.CutSuffix(meta, ";base64"); ok {
+				if data, err := base64.StdEncoding.DecodeString(payload); err == nil {
+					if mediaType == "" {
+						mediaType = http.DetectContentType(data)
+					}
+					return data, mediaType
+				}
+			}
+		}
+	}
+
+	return []byte(value), "text/plain; charset=utf-8"
+}
+
+// encodeOutputValue is the reverse of decodeInputValue: text is returned as is
+// and anything else as a base64 data URI of its detected media type
+func encodeOutputValue(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if strings.HasPrefix(contentType, "text/") && utf8.Valid(data) {
+		return string(data)
+	}
+
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// walkStrings rebuilds a decoded JSON value with every string passed through replace
+func walkStrings(value interface{}, path string, replace func(path, value string) (interface{}, error)) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return replace(path, v)
+
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			replaced, err := walkStrings(item, path+"."+key, replace)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = replaced
+		}
+		return out, nil
+
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			replaced, err := walkStrings(item, fmt.Sprintf("%s[%d]", path, i), replace)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = replaced
+		}
+		return out, nil
+	}
+
+	return value, nil
+}
@@ -3,7 +3,6 @@ package runpod
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 )
 
@@ -14,6 +13,16 @@ func (c *Client) CreatePod(ctx context.Context, req *CreatePodRequest) (*Pod, er
 		return nil, err
 	}
 
+	// Make sure referenced secrets exist before paying for a pod that cannot start
+	if err := c.ValidateEnvSecrets(ctx, req.Env); err != nil {
+		return nil, err
+	}
+
+	return c.createPod(ctx, req)
+}
+
+// createPod sends an already validated pod creation request
+func (c *Client) createPod(ctx context.Context, req *CreatePodRequest) (*Pod, error) {
 	var pod Pod
 	err := c.Post(ctx, "/pods", req, &pod)
 	if err != nil {
@@ -23,14 +32,6 @@ func (c *Client) CreatePod(ctx context.Context, req *CreatePodRequest) (*Pod, er
 	return &pod, nil
 }
 
-// CreateSpotPod creates a new spot/interruptible pod instance
-func (c *Client) CreateSpotPod(ctx context.Context, req *CreatePodRequest, bidPerGPU float64) (*Pod, error) {
-	// Set spot-specific fields
-	req.Interruptible = true
-
-	return c.CreatePod(ctx, req)
-}
-
 // GetPod retrieves a pod by ID
 func (c *Client) GetPod(ctx context.Context, podID string) (*Pod, error) {
 	if err := c.validateRequired("podID", podID); err != nil {
@@ -39,7 +40,7 @@ func (c *Client) GetPod(ctx context.Context, podID string) (*Pod, error) {
 
 	var pod Pod
 	endpoint := fmt.Sprintf("/pods/%s", podID)
-	err := c.Get(ctx, endpoint, &pod)
+	err := c.hedgedGet(ctx, endpoint, &pod)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pod %s: %w", podID, err)
 	}
@@ -63,6 +64,77 @@ func (c *Client) ListPods(ctx context.Context, opts *ListOptions) ([]*Pod, error
 	return response.Pods, nil
 }
 
+// UpdatePod changes the configuration of an existing pod.
+// RunPod resets the pod to apply image, disk and volume changes.
+func (c *Client) UpdatePod(ctx context.Context, podID string, req *UpdatePodRequest) (*Pod, error) {
+	if err := c.validateRequired("podID", podID); err != nil {
+		return nil, err
+	}
+	if err := c.validateUpdatePodRequest(req); err != nil {
+		return nil, err
+	}
+
+	var pod Pod
+	endpoint := fmt.Sprintf("/pods/%s", podID)
+	err := c.Patch(ctx, endpoint, req, &pod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pod %s: %w", podID, err)
+	}
+
+	return &pod, nil
+}
+
+// UpdatePodEnv applies an environment diff to a pod: keys in set are added or
+// overwritten, keys in unset are removed, and all other variables are kept.
+// If restart is true the pod is restarted so the new environment takes effect.
+func (c *Client) UpdatePodEnv(ctx context.Context, podID string, set map[string]string, unset []string, restart bool) (*Pod, error) {
+	pod, err := c.GetPod(ctx, podID)
+	if err != nil {
+		return nil, err
+	}
+
+	env := make(map[string]string, len(pod.Env)+len(set))
+	for key, value := range pod.Env {
+		env[key] = value
+	}
+	for key, value := range set {
+		env[key] = value
+	}
+	for _, key := range unset {
+		delete(env, key)
+	}
+
+	updated, err := c.UpdatePod(ctx, podID, &UpdatePodRequest{Env: env})
+	if err != nil {
+		return nil, err
+	}
+
+	if !restart {
+		return updated, nil
+	}
+
+	if err := c.RestartPod(ctx, podID); err != nil {
+		return updated, err
+	}
+
+	return updated, nil
+}
+
+// RestartPod restarts a pod's container without releasing its GPU
+func (c *Client) RestartPod(ctx context.Context, podID string) error {
+	if err := c.validateRequired("podID", podID); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("/pods/%s/restart", podID)
+	err := c.Post(ctx, endpoint, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to restart pod %s: %w", podID, err)
+	}
+
+	return nil
+}
+
 // StopPod stops a running pod
 func (c *Client) StopPod(ctx context.Context, podID string) error {
 	if err := c.validateRequired("podID", podID); err != nil {
@@ -137,24 +209,35 @@ func (c *Client) GetPodStatus(ctx context.Context, podID string) (string, error)
 	return pod.Status(), nil
 }
 
-// WaitForPodStatus waits for a pod to reach a specific status
-func (c *Client) WaitForPodStatus(ctx context.Context, podID string, targetStatus string, maxAttempts int) (*Pod, error) {
+// WaitForPodStatus waits for a pod to reach a specific status. It fails early
+// if the pod enters an error state or makes an impossible status transition
+// (see PodStatus.CanTransitionTo), returning a *PodTransitionError for the latter.
+func (c *Client) WaitForPodStatus(ctx context.Context, podID string, targetStatus PodStatus, maxAttempts int) (*Pod, error) {
 	if maxAttempts <= 0 {
 		maxAttempts = 30 // Default max attempts
 	}
+	targetStatus = ParsePodStatus(string(targetStatus))
 
+	var previous PodStatus
 	for attempt := 0; attempt < maxAttempts; attempt++ {
 		pod, err := c.GetPod(ctx, podID)
 		if err != nil {
 			return nil, err
 		}
 
-		if strings.ToUpper(pod.Status()) == strings.ToUpper(targetStatus) {
+		if previous != "" {
+			if err := ValidatePodTransition(podID, previous, pod.DesiredStatus); err != nil {
+				return pod, err
+			}
+		}
+		previous = pod.DesiredStatus
+
+		if pod.DesiredStatus == targetStatus {
 			return pod, nil
 		}
 
 		// Check if pod is in a terminal error state
-		if c.isPodInErrorState(pod.Status()) {
+		if isPodInErrorState(pod.DesiredStatus) {
 			return pod, fmt.Errorf("pod %s is in error state: %s", podID, pod.Status())
 		}
 
@@ -170,44 +253,29 @@ func (c *Client) WaitForPodStatus(ctx context.Context, podID string, targetStatu
 	return nil, fmt.Errorf("pod %s did not reach status %s after %d attempts", podID, targetStatus, maxAttempts)
 }
 
-// ListPodsByStatus lists pods filtered by status
-func (c *Client) ListPodsByStatus(ctx context.Context, status string, opts *ListOptions) ([]*Pod, error) {
-	pods, err := c.ListPods(ctx, opts)
-	if err != nil {
-		return nil, err
-	}
-
-	var filteredPods []*Pod
-	for _, pod := range pods {
-		if strings.ToUpper(pod.Status()) == strings.ToUpper(status) {
-			filteredPods = append(filteredPods, pod)
-		}
-	}
-
-	return filteredPods, nil
+// ListPodsByStatus lists pods filtered by status across all pages.
+// opts sets the page size and starting offset.
+func (c *Client) ListPodsByStatus(ctx context.Context, status PodStatus, opts *ListOptions) ([]*Pod, error) {
+	return c.collectPods(c.listPodsPaged(ctx, &PodFilter{Status: string(status)}, opts))
 }
 
 // ListRunningPods lists all currently running pods
 func (c *Client) ListRunningPods(ctx context.Context, opts *ListOptions) ([]*Pod, error) {
-	return c.ListPodsByStatus(ctx, "RUNNING", opts)
+	return c.ListPodsByStatus(ctx, PodStatusRunning, opts)
 }
 
 // ListStoppedPods lists all stopped pods
 func (c *Client) ListStoppedPods(ctx context.Context, opts *ListOptions) ([]*Pod, error) {
-	return c.ListPodsByStatus(ctx, "STOPPED", opts)
+	return c.ListPodsByStatus(ctx, PodStatusStopped, opts)
 }
 
-// FindPodByName finds a pod by its name
+// FindPodByName finds a pod by its name, searching all pages
 func (c *Client) FindPodByName(ctx context.Context, name string) (*Pod, error) {
-	pods, err := c.ListPods(ctx, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, pod := range pods {
-		if pod.Name == name {
-			return pod, nil
+	for pod, err := range c.ListPodsAll(ctx, &PodFilter{Name: name}) {
+		if err != nil {
+			return nil, err
 		}
+		return pod, nil
 	}
 
 	return nil, &APIError{
@@ -259,11 +327,14 @@ func (c *Client) validateCreatePodRequest(req *CreatePodRequest) error {
 	}
 
 	// Validate bid price for spot instances
-	// if req.BidPerGPU > 0 {
-	// 	if err := c.validatePositiveFloat("bidPerGpu", req.BidPerGPU); err != nil {
-	// 		return err
-	// 	}
-	// }
+	if req.BidPerGPU != 0 {
+		if err := c.validatePositiveFloat("bidPerGpu", req.BidPerGPU); err != nil {
+			return err
+		}
+		if !req.Interruptible {
+			return NewValidationError("bidPerGpu", "can only be set on interruptible pods")
+		}
+	}
 
 	// Validate cloud type
 	if req.CloudType != "" {
@@ -298,18 +369,35 @@ func (c *Client) validateCreatePodRequest(req *CreatePodRequest) error {
 	return nil
 }
 
-// isPodInErrorState checks if a pod is in a terminal error state
-func (c *Client) isPodInErrorState(status string) bool {
-	errorStates := []string{"EXITED", "DEAD", "TERMINATED", "FAILED"}
-	upperStatus := strings.ToUpper(status)
+// validateUpdatePodRequest validates a pod update request
+func (c *Client) validateUpdatePodRequest(req *UpdatePodRequest) error {
+	if req == nil {
+		return NewValidationError("request", "cannot be nil")
+	}
 
-	for _, errorState := range errorStates {
-		if upperStatus == errorState {
-			return true
+	if req.Name == "" && req.ImageName == "" && req.Env == nil && req.Ports == nil &&
+		req.ContainerDiskInGB == 0 && req.VolumeInGB == 0 && req.VolumeMountPath == "" &&
+		req.DockerEntrypoint == nil && req.DockerStartCmd == nil {
+		return NewValidationError("request", "must change at least one field")
+	}
+
+	if req.ContainerDiskInGB != 0 {
+		if err := c.validatePositive("containerDiskInGb", req.ContainerDiskInGB); err != nil {
+			return err
+		}
+	}
+	if req.VolumeInGB != 0 {
+		if err := c.validatePositive("volumeInGb", req.VolumeInGB); err != nil {
+			return err
 		}
 	}
 
-	return false
+	return nil
+}
+
+// isPodInErrorState checks if a pod has exited or is in a terminal state
+func isPodInErrorState(status PodStatus) bool {
+	return status == PodStatusExited || status.IsTerminal()
 }
 
 // // ================================
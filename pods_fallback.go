@@ -0,0 +1,135 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+)
+
+// PodFallbackOptions describes the ordered preferences walked by CreatePodWithFallback
+type PodFallbackOptions struct {
+	// GPUTypeIDs are tried in order, most preferred first.
+	// Defaults to the GPUTypeIDs of the base request.
+	GPUTypeIDs []string
+
+	// DataCenterIDs are tried in order for every GPU type and cloud type.
+	// When empty, the DataCenterIDs of the base request are used as-is.
+	DataCenterIDs []string
+
+	// CloudTypes are tried in order for every GPU type.
+	// Defaults to SECURE then COMMUNITY.
+	CloudTypes []string
+}
+
+// PodCreateAttempt records a single pod creation attempt made during fallback
+type PodCreateAttempt struct {
+	GPUTypeID    string
+	DataCenterID string
+	CloudType    string
+	Err          error
+}
+
+// PodFallbackResult reports the outcome of CreatePodWithFallback
+type PodFallbackResult struct {
+	// Pod is the created pod, nil if every attempt failed
+	Pod *Pod
+
+	// Succeeded is the attempt that created the pod, nil if every attempt failed
+	Succeeded *PodCreateAttempt
+
+	// Attempts lists every attempt in the order it was made
+	Attempts []PodCreateAttempt
+}
+
+// CreatePodWithFallback creates a pod, walking the preference lists in opts until
+// one combination has capacity. GPU types are the outermost preference, then
+// cloud types, then datacenters. Only "no capacity" errors move on to the next
+// combination; any other error is returned immediately.
+func (c *Client) CreatePodWithFallback(ctx context.Context, req *CreatePodRequest, opts *PodFallbackOptions) (*PodFallbackResult, error) {
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+	if opts == nil {
+		opts = &PodFallbackOptions{}
+	}
+
+	gpuTypeIDs := opts.GPUTypeIDs
+	if len(gpuTypeIDs) == 0 {
+		gpuTypeIDs = req.GPUTypeIDs
+	}
+
+	cloudTypes := opts.CloudTypes
+	if len(cloudTypes) == 0 {
+		cloudTypes = []string{"SECURE", "COMMUNITY"}
+	}
+
+	// An empty datacenter entry keeps whatever the base request specified
+	dataCenterIDs := opts.DataCenterIDs
+	if len(dataCenterIDs) == 0 {
+		dataCenterIDs = []string{""}
+	}
+
+	if err := c.ValidateEnvSecrets(ctx, req.Env); err != nil {
+		return nil, err
+	}
+
+	result := &PodFallbackResult{}
+
+	for _, gpuTypeID := range gpuTypeIDs {
+		for _, cloudType := range cloudTypes {
+			for _, dataCenterID := range dataCenterIDs {
+				attempt := PodCreateAttempt{
+					GPUTypeID:    gpuTypeID,
+					DataCenterID: dataCenterID,
+					CloudType:    cloudType,
+				}
+
+				attemptReq := fallbackPodRequest(req, attempt)
+				if err := c.validateCreatePodRequest(attemptReq); err != nil {
+					return result, err
+				}
+
+				if c.IsDebugEnabled() {
+					c.GetLogger().Printf("[DEBUG] Pod fallback attempt %d: gpu=%s cloud=%s datacenter=%s",
+						len(result.Attempts)+1, gpuTypeID, cloudType, dataCenterID)
+				}
+
+				pod, err := c.createPod(ctx, attemptReq)
+				attempt.Err = err
+				result.Attempts = append(result.Attempts, attempt)
+
+				if err == nil {
+					result.Pod = pod
+					result.Succeeded = &result.Attempts[len(result.Attempts)-1]
+					return result, nil
+				}
+
+				if !IsNoCapacityError(err) {
+					return result, err
+				}
+
+				if ctx.Err() != nil {
+					return result, ctx.Err()
+				}
+			}
+		}
+	}
+
+	if len(result.Attempts) == 0 {
+		return result, NewValidationError("gpuTypeIds", "cannot be empty")
+	}
+
+	lastErr := result.Attempts[len(result.Attempts)-1].Err
+	return result, fmt.Errorf("no capacity for any of %d pod configurations: %w", len(result.Attempts), lastErr)
+}
+
+// fallbackPodRequest copies the base request and applies a single attempt's choices
+func fallbackPodRequest(base *CreatePodRequest, attempt PodCreateAttempt) *CreatePodRequest {
+	req := *base
+	req.GPUTypeIDs = []string{attempt.GPUTypeID}
+	req.CloudType = attempt.CloudType
+	if attempt.DataCenterID != "" {
+		req.DataCenterIDs = []string{attempt.DataCenterID}
+	}
+
+	return &req
+}
@@ -0,0 +1,197 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// DefaultPageSize is the page size used when following paginated list endpoints
+const DefaultPageSize = 100
+
+// PodFilter selects pods by their attributes. Empty fields match everything.
+// Fields the REST API can filter on are sent as query parameters; every field
+// is also checked client-side, so results are correct either way.
+type PodFilter struct {
+	Status        string            // Desired status, case-insensitive (see PodStatus)
+	Name          string            // Exact pod name
+	NamePrefix    string            // Pod name prefix
+	ImageName     string            // Exact image name
+	GPUTypeID     string            // GPU type the pod runs on
+	DataCenterID  string            // Datacenter hosting the pod
+	Interruptible *bool             // Spot (true) or on-demand (false) pods
+	EnvLabels     map[string]string // Env variables that must be set to these values
+}
+
+// Matches reports whether a pod satisfies every field of the filter
+func (f *PodFilter) Matches(pod *Pod) bool {
+	if f == nil {
+		return true
+	}
+	if pod == nil {
+		return false
+	}
+
+	if f.Status != "" && pod.DesiredStatus != ParsePodStatus(f.Status) {
+		return false
+	}
+	if f.Name != "" && pod.Name != f.Name {
+		return false
+	}
+	if f.NamePrefix != "" && !strings.HasPrefix(pod.Name, f.NamePrefix) {
+		return false
+	}
+	if f.ImageName != "" && pod.ImageName != f.ImageName {
+		return false
+	}
+	if f.GPUTypeID != "" && pod.GPUTypeID() != f.GPUTypeID {
+		return false
+	}
+	if f.DataCenterID != "" && pod.DataCenterID() != f.DataCenterID {
+		return false
+	}
+	if f.Interruptible != nil && pod.Interruptible != *f.Interruptible {
+		return false
+	}
+	for key, value := range f.EnvLabels {
+		if actual, ok := pod.Env[key]; !ok || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isEmpty reports whether the filter matches every pod
+func (f *PodFilter) isEmpty() bool {
+	return f == nil || (f.Status == "" && f.Name == "" && f.NamePrefix == "" && f.ImageName == "" &&
+		f.GPUTypeID == "" && f.DataCenterID == "" && f.Interruptible == nil && len(f.EnvLabels) == 0)
+}
+
+// queryParams returns the filter fields supported by the REST API
+func (f *PodFilter) queryParams() map[string]string {
+	params := make(map[string]string)
+	if f == nil {
+		return params
+	}
+
+	if f.Status != "" {
+		params["desiredStatus"] = ParsePodStatus(f.Status).String()
+	}
+	if f.Name != "" {
+		params["name"] = f.Name
+	}
+	if f.ImageName != "" {
+		params["imageName"] = f.ImageName
+	}
+	if f.GPUTypeID != "" {
+		params["gpuTypeId"] = f.GPUTypeID
+	}
+	if f.DataCenterID != "" {
+		params["dataCenterId"] = f.DataCenterID
+	}
+
+	return params
+}
+
+// ListPodsAll returns an iterator over every pod matching filter, following
+// Limit/Offset pagination until the last page. Iteration stops after the first error.
+func (c *Client) ListPodsAll(ctx context.Context, filter *PodFilter) iter.Seq2[*Pod, error] {
+	return c.listPodsPaged(ctx, filter, nil)
+}
+
+// ListPodsFiltered collects every pod matching filter across all pages
+func (c *Client) ListPodsFiltered(ctx context.Context, filter *PodFilter) ([]*Pod, error) {
+	return c.collectPods(c.listPodsPaged(ctx, filter, nil))
+}
+
+// listPodsPaged iterates over all pages of /pods. opts sets the page size and starting offset.
+func (c *Client) listPodsPaged(ctx context.Context, filter *PodFilter, opts *ListOptions) iter.Seq2[*Pod, error] {
+	pageSize := DefaultPageSize
+	offset := 0
+	if opts != nil {
+		if opts.Limit > 0 {
+			pageSize = opts.Limit
+		}
+		offset = opts.Offset
+	}
+
+	fetch := func(limit, offset int) ([]*Pod, error) {
+		params := filter.queryParams()
+		params["limit"] = strconv.Itoa(limit)
+		if offset > 0 {
+			params["offset"] = strconv.Itoa(offset)
+		}
+
+		var response struct {
+			Pods []*Pod `json:"pods"`
+		}
+
+		endpoint := c.buildURLWithParams("/pods", params)
+		if err := c.Get(ctx, endpoint, &response); err != nil {
+			return nil, fmt.Errorf("failed to list pods at offset %d: %w", offset, err)
+		}
+		return response.Pods, nil
+	}
+
+	return func(yield func(*Pod, error) bool) {
+		for pod, err := range paginate(pageSize, offset, fetch, func(pod *Pod) string { return pod.ID }) {
+			if err == nil && !filter.Matches(pod) {
+				continue
+			}
+			if !yield(pod, err) {
+				return
+			}
+		}
+	}
+}
+
+// paginate iterates over every item of an offset-paginated list, starting at
+// offset. fetch returns one page; key identifies items so that repeated items
+// are skipped. Nil items are skipped too.
+func paginate[T any](pageSize, offset int, fetch func(limit, offset int) ([]*T, error), key func(*T) string) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		seen := make(map[string]bool)
+
+		for {
+			page, err := fetch(pageSize, offset)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			fresh := 0
+			for _, item := range page {
+				// Guard against servers that ignore the offset and repeat pages
+				if item == nil || seen[key(item)] {
+					continue
+				}
+				seen[key(item)] = true
+				fresh++
+
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(page) < pageSize || fresh == 0 {
+				return
+			}
+			offset += len(page)
+		}
+	}
+}
+
+// collectPods drains a pod iterator into a slice
+func (c *Client) collectPods(pods iter.Seq2[*Pod, error]) ([]*Pod, error) {
+	var result []*Pod
+	for pod, err := range pods {
+		if err != nil {
+			return result, err
+		}
+		result = append(result, pod)
+	}
+	return result, nil
+}
@@ -0,0 +1,199 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// LogLine is a single line of pod output
+type LogLine struct {
+	Text string
+
+	// Timestamp is parsed from the start of the line, zero if the line has none
+	Timestamp time.Time
+}
+
+// TailLogsOptions configures TailPodLogs and NewPodLogReader
+type TailLogsOptions struct {
+	// PollInterval is how often logs are fetched. Defaults to 2 seconds.
+	PollInterval time.Duration
+
+	// Since skips lines timestamped before this time. Lines without a
+	// timestamp are always delivered.
+	Since time.Time
+
+	// TailLines limits the first fetch to its last N lines. Zero delivers the whole backlog.
+	TailLines int
+
+	// MaxLines stops tailing after N lines have been delivered. Zero means no limit.
+	MaxLines int
+}
+
+// TailPodLogs polls a pod's logs and delivers only the lines that are new since
+// the previous fetch. Both channels are closed when tailing stops, which happens
+// when ctx is cancelled, MaxLines is reached or fetching logs fails.
+func (c *Client) TailPodLogs(ctx context.Context, podID string, opts *TailLogsOptions) (<-chan LogLine, <-chan error) {
+	if opts == nil {
+		opts = &TailLogsOptions{}
+	}
+
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 2 * time.Second // Default poll interval
+	}
+
+	lineChan := make(chan LogLine, 64)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(lineChan)
+		defer close(errChan)
+
+		var previous []string
+		delivered := 0
+		first := true
+
+		for {
+			logs, err := c.GetPodLogs(ctx, podID)
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			current := splitLogLines(logs)
+			fresh := newLogLines(previous, current)
+			previous = current
+
+			if first && opts.TailLines > 0 && len(fresh) > opts.TailLines {
+				fresh = fresh[len(fresh)-opts.TailLines:]
+			}
+			first = false
+
+			for _, text := range fresh {
+				line := parseLogLine(text)
+				if !opts.Since.IsZero() && !line.Timestamp.IsZero() && line.Timestamp.Before(opts.Since) {
+					continue
+				}
+
+				select {
+				case lineChan <- line:
+					delivered++
+				case <-ctx.Done():
+					errChan <- ctx.Err()
+					return
+				}
+
+				if opts.MaxLines > 0 && delivered >= opts.MaxLines {
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				errChan <- ctx.Err()
+				return
+			case <-time.After(pollInterval):
+				// Continue polling
+			}
+		}
+	}()
+
+	return lineChan, errChan
+}
+
+// NewPodLogReader returns a reader that yields a pod's log lines, newline
+// terminated, as TailPodLogs delivers them. Closing the reader stops tailing.
+func (c *Client) NewPodLogReader(ctx context.Context, podID string, opts *TailLogsOptions) io.ReadCloser {
+	ctx, cancel := context.WithCancel(ctx)
+	pr, pw := io.Pipe()
+
+	lines, errs := c.TailPodLogs(ctx, podID, opts)
+
+	go func() {
+		for line := range lines {
+			if _, err := io.WriteString(pw, line.Text+"\n"); err != nil {
+				cancel()
+				break
+			}
+		}
+
+		// Drain remaining lines so the tailing goroutine can exit
+		for range lines {
+		}
+
+		err := <-errs
+		if errors.Is(err, context.Canceled) {
+			err = nil
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return &podLogReader{PipeReader: pr, cancel: cancel}
+}
+
+// podLogReader stops the underlying tail when closed
+type podLogReader struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+func (r *podLogReader) Close() error {
+	r.cancel()
+	return r.PipeReader.Close()
+}
+
+// splitLogLines splits a log snapshot into lines, dropping the trailing empty line
+func splitLogLines(logs string) []string {
+	logs = strings.TrimRight(logs, "\n")
+	if logs == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(logs, "\r\n", "\n"), "\n")
+}
+
+// newLogLines returns the lines of current that follow its overlap with previous.
+// Logs are returned as a sliding window, so the longest suffix of previous that
+// is also a prefix of current marks where the new output starts.
+func newLogLines(previous, current []string) []string {
+	overlap := len(previous)
+	if len(current) < overlap {
+		overlap = len(current)
+	}
+
+	for ; overlap > 0; overlap-- {
+		if equalLines(previous[len(previous)-overlap:], current[:overlap]) {
+			break
+		}
+	}
+
+	return current[overlap:]
+}
+
+// equalLines compares two line slices of the same length
+func equalLines(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLogLine extracts a leading RFC 3339 timestamp from a log line if present
+func parseLogLine(text string) LogLine {
+	line := LogLine{Text: text}
+
+	field := text
+	if i := strings.IndexByte(text, ' '); i > 0 {
+		field = text[:i]
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, field); err == nil {
+		line.Timestamp = t
+	}
+
+	return line
+}
@@ -0,0 +1,205 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CreateSpotPod creates a new spot/interruptible pod bidding bidPerGPU $/hr per GPU.
+// If bidPerGPU is zero the request's own BidPerGPU is used. The bid is checked
+// against the minimum bid price of every requested GPU type before the pod is
+// created. The caller's request is never modified.
+func (c *Client) CreateSpotPod(ctx context.Context, req *CreatePodRequest, bidPerGPU float64) (*Pod, error) {
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+
+	// Copy the request so the caller's value keeps its original settings
+	spotReq := *req
+	spotReq.Interruptible = true
+	if bidPerGPU != 0 {
+		spotReq.BidPerGPU = bidPerGPU
+	}
+
+	if spotReq.BidPerGPU == 0 {
+		return nil, NewValidationError("bidPerGpu", "is required for spot pods")
+	}
+
+	if err := c.validateCreatePodRequest(&spotReq); err != nil {
+		return nil, err
+	}
+
+	if err := c.ValidateEnvSecrets(ctx, spotReq.Env); err != nil {
+		return nil, err
+	}
+
+	if err := c.validateSpotBid(ctx, &spotReq); err != nil {
+		return nil, err
+	}
+
+	return c.createPod(ctx, &spotReq)
+}
+
+// validateSpotBid checks the bid against the minimum bid price of each
+// requested GPU type. Minimum bids are only published through GraphQL.
+func (c *Client) validateSpotBid(ctx context.Context, req *CreatePodRequest) error {
+	opts := &GPUAvailabilityOptions{GPUCount: req.GPUCount}
+	switch req.CloudType {
+	case "SECURE":
+		secure := true
+		opts.SecureCloud = &secure
+	case "COMMUNITY":
+		secure := false
+		opts.SecureCloud = &secure
+	}
+	if len(req.DataCenterIDs) == 1 {
+		opts.DataCenterID = req.DataCenterIDs[0]
+	}
+
+	for _, gpuTypeID := range req.GPUTypeIDs {
+		opts.GPUTypeID = gpuTypeID
+		gpuTypes, err := c.GetGPUAvailability(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to check minimum bid price: %w", err)
+		}
+
+		var minimumBid float64
+		for _, gpuType := range gpuTypes {
+			if gpuType.ID == gpuTypeID && gpuType.LowestPrice != nil {
+				minimumBid = gpuType.LowestPrice.MinimumBidPrice
+			}
+		}
+		if minimumBid <= 0 {
+			// No price information for this GPU type, so leave the bid to the API
+			if c.IsDebugEnabled() {
+				c.GetLogger().Printf("[DEBUG] No minimum bid price found for %s, skipping bid check", gpuTypeID)
+			}
+			continue
+		}
+
+		if req.BidPerGPU < minimumBid {
+			return NewValidationErrorWithValue("bidPerGpu",
+				fmt.Sprintf("must be at least the minimum bid price of %.3f for %s", minimumBid, gpuTypeID),
+				req.BidPerGPU)
+		}
+	}
+
+	return nil
+}
+
+// spotMachineGracePeriod is how long a newly created or started spot pod may
+// wait for a machine before it counts as preempted
+const spotMachineGracePeriod = 5 * time.Minute
+
+// IsSpotPodPreempted reports whether an interruptible pod lost its machine
+// while it is still meant to be running, which for spot pods means it was
+// outbid or reclaimed. Pods that exited or were stopped on purpose do not
+// count, nor do pods created or started within the last five minutes, which
+// may still be waiting for a machine to be assigned.
+func IsSpotPodPreempted(pod *Pod) bool {
+	if pod == nil || !pod.Interruptible {
+		return false
+	}
+	if pod.DesiredStatus != PodStatusRunning || pod.MachineID != "" || pod.Machine != nil {
+		return false
+	}
+
+	for _, started := range []*JSONTime{pod.CreatedAt, pod.LastStartedAt} {
+		if started != nil && !started.Time.IsZero() && time.Since(started.Time) < spotMachineGracePeriod {
+			return false
+		}
+	}
+	return true
+}
+
+// SpotRelaunchOptions configures KeepSpotPodRunning
+type SpotRelaunchOptions struct {
+	// PollInterval is how often the pod is checked. Defaults to 30 seconds.
+	PollInterval time.Duration
+
+	// MaxRelaunches limits how many times the pod is relaunched. Zero means no limit.
+	MaxRelaunches int
+
+	// TerminatePreempted terminates the preempted pod after a replacement is created
+	TerminatePreempted bool
+
+	// OnRelaunch is called after a preempted pod has been replaced. preempted is
+	// the pod as last seen before it was preempted.
+	OnRelaunch func(preempted, relaunched *Pod)
+}
+
+// KeepSpotPodRunning watches a spot pod and relaunches it from req with the
+// given bid whenever it is preempted. A pod that disappears after it was first
+// seen counts as reclaimed and is relaunched too, so cancel ctx before
+// terminating a watched pod. It blocks until ctx is cancelled, a check or
+// relaunch fails or MaxRelaunches is exceeded, and returns the most recent pod.
+// A pod that exits or is stopped is not relaunched.
+func (c *Client) KeepSpotPodRunning(ctx context.Context, podID string, req *CreatePodRequest, bidPerGPU float64, opts *SpotRelaunchOptions) (*Pod, error) {
+	if err := c.validateRequired("podID", podID); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &SpotRelaunchOptions{}
+	}
+
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 30 * time.Second
+	}
+
+	var current *Pod
+	relaunches := 0
+
+	for {
+		preempted := false
+		pod, err := c.GetPod(ctx, podID)
+		switch {
+		case err == nil:
+			current = pod
+			preempted = IsSpotPodPreempted(pod)
+		case ctx.Err() != nil:
+			return current, ctx.Err()
+		case isNotFoundError(err) && current != nil:
+			// Reclaimed spot pods can disappear entirely
+			preempted = true
+		default:
+			return current, err
+		}
+
+		if preempted {
+			if opts.MaxRelaunches > 0 && relaunches >= opts.MaxRelaunches {
+				return current, fmt.Errorf("spot pod %s was preempted after %d relaunches", podID, relaunches)
+			}
+
+			if c.IsDebugEnabled() {
+				c.GetLogger().Printf("[DEBUG] Spot pod %s preempted (status %s), relaunching", podID, current.Status())
+			}
+
+			relaunched, err := c.CreateSpotPod(ctx, req, bidPerGPU)
+			if err != nil {
+				return current, fmt.Errorf("failed to relaunch preempted spot pod %s: %w", podID, err)
+			}
+			relaunches++
+
+			if opts.TerminatePreempted {
+				if err := c.TerminatePod(ctx, podID); err != nil && !isNotFoundError(err) {
+					return relaunched, err
+				}
+			}
+
+			if opts.OnRelaunch != nil {
+				opts.OnRelaunch(current, relaunched)
+			}
+
+			current = relaunched
+			podID = relaunched.ID
+		}
+
+		select {
+		case <-ctx.Done():
+			return current, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
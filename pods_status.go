@@ -0,0 +1,151 @@
+package runpod
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PodStatus is the desired status of a pod. Values are normalized to upper case
+// when decoded, so comparisons against the constants are case-insensitive.
+type PodStatus string
+
+const (
+	PodStatusCreated    PodStatus = "CREATED"
+	PodStatusRunning    PodStatus = "RUNNING"
+	PodStatusRestarting PodStatus = "RESTARTING"
+	PodStatusPaused     PodStatus = "PAUSED"
+	PodStatusStopped    PodStatus = "STOPPED"
+	PodStatusExited     PodStatus = "EXITED"
+	PodStatusFailed     PodStatus = "FAILED"
+	PodStatusDead       PodStatus = "DEAD"
+	PodStatusTerminated PodStatus = "TERMINATED"
+)
+
+// podTransitions lists the statuses each known status can move to. Staying in
+// the same status is always allowed and is not listed.
+var podTransitions = map[PodStatus][]PodStatus{
+	PodStatusCreated: {
+		PodStatusRunning, PodStatusRestarting, PodStatusPaused, PodStatusStopped,
+		PodStatusExited, PodStatusFailed, PodStatusDead, PodStatusTerminated,
+	},
+	PodStatusRunning: {
+		PodStatusRestarting, PodStatusPaused, PodStatusStopped,
+		PodStatusExited, PodStatusFailed, PodStatusDead, PodStatusTerminated,
+	},
+	PodStatusRestarting: {
+		PodStatusRunning, PodStatusStopped, PodStatusExited, PodStatusFailed, PodStatusDead, PodStatusTerminated,
+	},
+	PodStatusPaused: {
+		PodStatusRunning, PodStatusRestarting, PodStatusStopped, PodStatusExited, PodStatusDead, PodStatusTerminated,
+	},
+	PodStatusStopped: {
+		PodStatusCreated, PodStatusRunning, PodStatusRestarting, PodStatusExited, PodStatusDead, PodStatusTerminated,
+	},
+	PodStatusExited: {
+		PodStatusCreated, PodStatusRunning, PodStatusRestarting, PodStatusStopped, PodStatusDead, PodStatusTerminated,
+	},
+	PodStatusFailed: {PodStatusTerminated},
+	PodStatusDead:   {PodStatusTerminated},
+}
+
+// ParsePodStatus normalizes a status string, for example "running" to PodStatusRunning
+func ParsePodStatus(status string) PodStatus {
+	return PodStatus(strings.ToUpper(strings.TrimSpace(status)))
+}
+
+// String returns the status as a string
+func (s PodStatus) String() string {
+	return string(s)
+}
+
+// IsKnown reports whether the status is one of the PodStatus constants
+func (s PodStatus) IsKnown() bool {
+	if s == PodStatusTerminated {
+		return true
+	}
+	_, ok := podTransitions[s]
+	return ok
+}
+
+// IsRunning reports whether the pod is up
+func (s PodStatus) IsRunning() bool {
+	return s == PodStatusRunning
+}
+
+// IsStopped reports whether the pod is stopped but can still be resumed
+func (s PodStatus) IsStopped() bool {
+	switch s {
+	case PodStatusPaused, PodStatusStopped, PodStatusExited:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether the pod can no longer be resumed. Dead and failed
+// pods can only be terminated; terminated pods are gone.
+func (s PodStatus) IsTerminal() bool {
+	switch s {
+	case PodStatusFailed, PodStatusDead, PodStatusTerminated:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether a pod in this status can move to next.
+// Unknown statuses are allowed to move anywhere, so statuses RunPod adds later
+// do not break callers.
+func (s PodStatus) CanTransitionTo(next PodStatus) bool {
+	if s == next || !s.IsKnown() || !next.IsKnown() {
+		return true
+	}
+
+	for _, allowed := range podTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalJSON decodes a status, normalizing its case
+func (s *PodStatus) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = ""
+		return nil
+	}
+
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("runpod.PodStatus: %w", err)
+	}
+
+	*s = ParsePodStatus(raw)
+	return nil
+}
+
+// MarshalJSON encodes the status in upper case
+func (s PodStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(ParsePodStatus(string(s))))
+}
+
+// PodTransitionError reports a status change that a pod cannot make, which
+// usually means the pod was replaced or its status was misreported
+type PodTransitionError struct {
+	PodID string
+	From  PodStatus
+	To    PodStatus
+}
+
+// Error implements the error interface
+func (e *PodTransitionError) Error() string {
+	return fmt.Sprintf("pod %s made an impossible status transition from %s to %s", e.PodID, e.From, e.To)
+}
+
+// ValidatePodTransition returns a *PodTransitionError if a pod cannot move from one status to the other
+func ValidatePodTransition(podID string, from, to PodStatus) error {
+	if !from.CanTransitionTo(to) {
+		return &PodTransitionError{PodID: podID, From: from, To: to}
+	}
+	return nil
+}
@@ -0,0 +1,326 @@
+package runpod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReapAction is what the reaper does to an idle pod
+type ReapAction string
+
+const (
+	ReapActionStop      ReapAction = "stop"
+	ReapActionTerminate ReapAction = "terminate"
+)
+
+// ReaperRule decides when a running pod is idle. A pod is reaped by the first
+// rule whose Filter matches it and whose limits it exceeds. A rule with no
+// limits reaps every matching pod, which suits name or label conventions such
+// as "tmp-" pods.
+type ReaperRule struct {
+	// Name identifies the rule in the audit log
+	Name string
+
+	// Filter restricts the rule to matching pods, for example by NamePrefix or EnvLabels
+	Filter *PodFilter
+
+	// MaxUptime reaps pods running longer than this since they last started
+	MaxUptime time.Duration
+
+	// MaxLifetime reaps pods created longer ago than this
+	MaxLifetime time.Duration
+
+	// HeartbeatTimeout reaps pods without a recorded heartbeat for this long.
+	// Pods that never sent one are measured from when they last started.
+	HeartbeatTimeout time.Duration
+
+	// Action defaults to ReapActionStop
+	Action ReapAction
+}
+
+// HeartbeatStore records when pods last reported activity
+type HeartbeatStore interface {
+	RecordHeartbeat(podID string, at time.Time)
+	LastHeartbeat(podID string) (time.Time, bool)
+}
+
+// MemoryHeartbeatStore is an in-process HeartbeatStore
+type MemoryHeartbeatStore struct {
+	mu         sync.Mutex
+	heartbeats map[string]time.Time
+}
+
+// NewMemoryHeartbeatStore creates an empty heartbeat store
+func NewMemoryHeartbeatStore() *MemoryHeartbeatStore {
+	return &MemoryHeartbeatStore{heartbeats: make(map[string]time.Time)}
+}
+
+// RecordHeartbeat stores at as the pod's latest heartbeat, ignoring older times
+func (s *MemoryHeartbeatStore) RecordHeartbeat(podID string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if last, ok := s.heartbeats[podID]; !ok || at.After(last) {
+		s.heartbeats[podID] = at
+	}
+}
+
+// LastHeartbeat returns the pod's latest heartbeat
+func (s *MemoryHeartbeatStore) LastHeartbeat(podID string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	at, ok := s.heartbeats[podID]
+	return at, ok
+}
+
+// ReaperConfig configures a Reaper
+type ReaperConfig struct {
+	// Rules are evaluated in order for every running pod
+	Rules []ReaperRule
+
+	// Allowlist holds pod IDs or names that are never reaped
+	Allowlist []string
+
+	// DryRun records what would be reaped without stopping anything
+	DryRun bool
+
+	// Interval is how often Run checks pods. Defaults to 5 minutes.
+	Interval time.Duration
+
+	// AuditLog receives one JSON line per reap event
+	AuditLog io.Writer
+
+	// Heartbeats defaults to an in-memory store
+	Heartbeats HeartbeatStore
+}
+
+// ReapEvent records an action taken, or planned in dry-run mode, on a pod
+type ReapEvent struct {
+	Time    time.Time  `json:"time"`
+	PodID   string     `json:"podId"`
+	PodName string     `json:"podName"`
+	Rule    string     `json:"rule"`
+	Action  ReapAction `json:"action"`
+	Reason  string     `json:"reason"`
+	DryRun  bool       `json:"dryRun"`
+	Error   string     `json:"error,omitempty"`
+}
+
+// Reaper stops or terminates running pods that its rules consider idle
+type Reaper struct {
+	client    *Client
+	cfg       ReaperConfig
+	allowlist map[string]bool
+
+	auditMu sync.Mutex
+}
+
+// NewReaper validates cfg and creates a reaper
+func (c *Client) NewReaper(cfg ReaperConfig) (*Reaper, error) {
+	if len(cfg.Rules) == 0 {
+		return nil, NewValidationError("Rules", "at least one rule is required")
+	}
+
+	cfg.Rules = append([]ReaperRule(nil), cfg.Rules...)
+	for i := range cfg.Rules {
+		rule := &cfg.Rules[i]
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		switch rule.Action {
+		case "":
+			rule.Action = ReapActionStop
+		case ReapActionStop, ReapActionTerminate:
+		default:
+			return nil, NewValidationErrorWithValue(fmt.Sprintf("Rules[%d].Action", i), "must be stop or terminate", rule.Action)
+		}
+		if rule.MaxUptime < 0 || rule.MaxLifetime < 0 || rule.HeartbeatTimeout < 0 {
+			return nil, NewValidationError(fmt.Sprintf("Rules[%d]", i), "limits cannot be negative")
+		}
+		if rule.Filter.isEmpty() && rule.MaxUptime == 0 && rule.MaxLifetime == 0 && rule.HeartbeatTimeout == 0 {
+			return nil, NewValidationError(fmt.Sprintf("Rules[%d]", i), "needs a filter or a limit, otherwise it reaps every pod")
+		}
+	}
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Minute
+	}
+	if cfg.Heartbeats == nil {
+		cfg.Heartbeats = NewMemoryHeartbeatStore()
+	}
+
+	allowlist := make(map[string]bool, len(cfg.Allowlist))
+	for _, entry := range cfg.Allowlist {
+		allowlist[entry] = true
+	}
+
+	return &Reaper{client: c, cfg: cfg, allowlist: allowlist}, nil
+}
+
+// RecordHeartbeat marks a pod as active now
+func (r *Reaper) RecordHeartbeat(podID string) {
+	r.cfg.Heartbeats.RecordHeartbeat(podID, time.Now())
+}
+
+// HeartbeatHandler returns an HTTP handler pods can POST to, with their ID in
+// the last path segment or the podId query parameter, to record a heartbeat
+func (r *Reaper) HeartbeatHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		podID := req.URL.Query().Get("podId")
+		if podID == "" {
+			podID = req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		}
+		if podID == "" {
+			http.Error(w, "pod ID is required", http.StatusBadRequest)
+			return
+		}
+
+		r.RecordHeartbeat(podID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// RunOnce checks every running pod once and reaps the idle ones. Failed
+// actions are reported in the returned events rather than as an error.
+func (r *Reaper) RunOnce(ctx context.Context) ([]ReapEvent, error) {
+	pods, err := r.client.ListPodsFiltered(ctx, &PodFilter{Status: string(PodStatusRunning)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list running pods: %w", err)
+	}
+
+	var events []ReapEvent
+	now := time.Now()
+
+	for _, pod := range pods {
+		if r.allowlist[pod.ID] || (pod.Name != "" && r.allowlist[pod.Name]) {
+			continue
+		}
+
+		rule, reason := r.evaluate(pod, now)
+		if rule == nil {
+			continue
+		}
+
+		event := ReapEvent{
+			Time:    now,
+			PodID:   pod.ID,
+			PodName: pod.Name,
+			Rule:    rule.Name,
+			Action:  rule.Action,
+			Reason:  reason,
+			DryRun:  r.cfg.DryRun,
+		}
+
+		if !r.cfg.DryRun {
+			if err := r.reap(ctx, pod.ID, rule.Action); err != nil {
+				event.Error = err.Error()
+			}
+		}
+
+		if r.client.IsDebugEnabled() {
+			r.client.GetLogger().Printf("[DEBUG] Reaper %s pod %s (%s): %s", rule.Action, pod.ID, rule.Name, reason)
+		}
+
+		r.audit(event)
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// Run calls RunOnce every Interval until ctx is cancelled
+func (r *Reaper) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			r.client.GetLogger().Printf("[REAPER] %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// evaluate returns the first rule that reaps pod, with the reason
+func (r *Reaper) evaluate(pod *Pod, now time.Time) (*ReaperRule, string) {
+	for i := range r.cfg.Rules {
+		rule := &r.cfg.Rules[i]
+		if !rule.Filter.Matches(pod) {
+			continue
+		}
+
+		if rule.MaxUptime == 0 && rule.MaxLifetime == 0 && rule.HeartbeatTimeout == 0 {
+			return rule, "matches filter"
+		}
+
+		if rule.MaxUptime > 0 && pod.LastStartedAt != nil && !pod.LastStartedAt.IsZero() {
+			if uptime := now.Sub(pod.LastStartedAt.Time); uptime > rule.MaxUptime {
+				return rule, fmt.Sprintf("up for %s, limit %s", uptime.Round(time.Second), rule.MaxUptime)
+			}
+		}
+
+		if rule.MaxLifetime > 0 && pod.CreatedAt != nil && !pod.CreatedAt.IsZero() {
+			if lifetime := now.Sub(pod.CreatedAt.Time); lifetime > rule.MaxLifetime {
+				return rule, fmt.Sprintf("created %s ago, limit %s", lifetime.Round(time.Second), rule.MaxLifetime)
+			}
+		}
+
+		if rule.HeartbeatTimeout > 0 {
+			last, ok := r.cfg.Heartbeats.LastHeartbeat(pod.ID)
+			if !ok && pod.LastStartedAt != nil {
+				last = pod.LastStartedAt.Time
+			}
+			if !last.IsZero() {
+				if silence := now.Sub(last); silence > rule.HeartbeatTimeout {
+					return rule, fmt.Sprintf("no heartbeat for %s, limit %s", silence.Round(time.Second), rule.HeartbeatTimeout)
+				}
+			}
+		}
+	}
+
+	return nil, ""
+}
+
+// reap stops or terminates a pod
+func (r *Reaper) reap(ctx context.Context, podID string, action ReapAction) error {
+	if action == ReapActionTerminate {
+		return r.client.TerminatePod(ctx, podID)
+	}
+	return r.client.StopPod(ctx, podID)
+}
+
+// audit appends an event to the audit log
+func (r *Reaper) audit(event ReapEvent) {
+	if r.cfg.AuditLog == nil {
+		return
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	r.auditMu.Lock()
+	defer r.auditMu.Unlock()
+
+	if _, err := r.cfg.AuditLog.Write(append(data, '\n')); err != nil {
+		r.client.GetLogger().Printf("[REAPER] Failed to write audit log: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package runpod
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestOption overrides a client setting for the calls made through a
+// derived client (see With). Unlike ClientOption it never changes state that
+// the parent client shares, such as its HTTP client.
+type RequestOption func(*clientConfig)
+
+// WithRequestTimeout sets the HTTP timeout for each request. Zero disables the
+// timeout, leaving only the context deadline.
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(c *clientConfig) {
+		c.setTimeout(timeout)
+	}
+}
+
+// WithRequestRetries sets the maximum number of retry attempts. Zero disables retries.
+func WithRequestRetries(maxAttempts int) RequestOption {
+	return func(c *clientConfig) {
+		c.maxRetryAttempts = maxAttempts
+	}
+}
+
+// WithRequestRetryDelay sets the base delay between retry attempts
+func WithRequestRetryDelay(delay time.Duration) RequestOption {
+	return func(c *clientConfig) {
+		c.retryDelay = delay
+	}
+}
+
+// WithRequestHeader adds a header to every request, replacing any value the
+// client would set itself
+func WithRequestHeader(key, value string) RequestOption {
+	return func(c *clientConfig) {
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+		c.headers.Set(key, value)
+	}
+}
+
+// WithRequestBaseURL sets the REST API base URL
+func WithRequestBaseURL(baseURL string) RequestOption {
+	return func(c *clientConfig) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithRequestServerlessBaseURL sets the base URL for serverless operations
+func WithRequestServerlessBaseURL(baseURL string) RequestOption {
+	return func(c *clientConfig) {
+		c.serverlessBaseURL = baseURL
+	}
+}
+
+// With returns a client that shares this client's connections and settings
+// but applies opts to its own requests, leaving c unchanged:
+//
+//	job, err := client.With(runpod.WithRequestTimeout(5*time.Minute)).RunSync(ctx, endpointID, input)
+//	health, err := client.With(runpod.WithRequestTimeout(2*time.Second), runpod.WithRequestRetries(0)).GetHealth(ctx, endpointID)
+func (c *Client) With(opts ...RequestOption) *Client {
+	cfg := c.snapshot().clone()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	derived := &Client{}
+	derived.config.Store(cfg)
+	return derived
+}
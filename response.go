@@ -0,0 +1,140 @@
+package runpod
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// debugBodyLimit is how much of a response body debug logging shows
+const debugBodyLimit = 4 << 10
+
+// WithMaxResponseSize limits how many bytes of a response body the client
+// reads. Larger responses fail with a *ResponseTooLargeError instead of being
+// held in memory. Zero, the default, means no limit.
+func WithMaxResponseSize(maxBytes int64) ClientOption {
+	return func(c *clientConfig) {
+		c.maxResponseSize = maxBytes
+	}
+}
+
+// WithRequestMaxResponseSize limits response bodies for a derived client (see WithMaxResponseSize)
+func WithRequestMaxResponseSize(maxBytes int64) RequestOption {
+	return func(c *clientConfig) {
+		c.maxResponseSize = maxBytes
+	}
+}
+
+// ResponseTooLargeError is returned when a response body exceeds the limit set
+// with WithMaxResponseSize
+type ResponseTooLargeError struct {
+	Limit int64
+
+	// ContentLength is the size the server announced, or -1 if unknown
+	ContentLength int64
+}
+
+// Error implements the error interface
+func (e *ResponseTooLargeError) Error() string {
+	if e.ContentLength >= 0 {
+		return fmt.Sprintf("response of %d bytes exceeds the %d byte limit", e.ContentLength, e.Limit)
+	}
+	return fmt.Sprintf("response exceeds the %d byte limit", e.Limit)
+}
+
+// IsResponseTooLargeError checks if an error, or any error it wraps, is a ResponseTooLargeError
+func IsResponseTooLargeError(err error) bool {
+	var tooLarge *ResponseTooLargeError
+	return errors.As(err, &tooLarge)
+}
+
+// bodyReader returns the response body, limited to the configured maximum size
+func (cfg *clientConfig) bodyReader(resp *http.Response) (io.Reader, error) {
+	if cfg.maxResponseSize <= 0 {
+		return resp.Body, nil
+	}
+	if resp.ContentLength > cfg.maxResponseSize {
+		return nil, &ResponseTooLargeError{Limit: cfg.maxResponseSize, ContentLength: resp.ContentLength}
+	}
+	return &limitedBody{r: resp.Body, remaining: cfg.maxResponseSize, limit: cfg.maxResponseSize}, nil
+}
+
+// readBody reads the whole response body, within the configured maximum size
+func (cfg *clientConfig) readBody(resp *http.Response) ([]byte, error) {
+	reader, err := cfg.bodyReader(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		if IsResponseTooLargeError(err) {
+			return nil, err
+		}
+		return nil, NewNetworkError("failed to read response body", err)
+	}
+	return body, nil
+}
+
+// logResponseBody logs the start of a response body in debug mode
+func (cfg *clientConfig) logResponseBody(body []byte) {
+	if !cfg.debug {
+		return
+	}
+	logged := &debugBody{}
+	logged.Write(body)
+	cfg.logger.Printf("[DEBUG] Response Body: %s", logged)
+}
+
+// limitedBody reads up to limit bytes and fails if the body holds more
+type limitedBody struct {
+	r         io.Reader
+	remaining int64
+	limit     int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// Only fail if there really is more data
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, &ResponseTooLargeError{Limit: l.limit, ContentLength: -1}
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// debugBody keeps the start of a response body for debug logging
+type debugBody struct {
+	buf   bytes.Buffer
+	total int64
+}
+
+func (d *debugBody) Write(p []byte) (int, error) {
+	d.total += int64(len(p))
+	if room := debugBodyLimit - d.buf.Len(); room > 0 {
+		if len(p) > room {
+			d.buf.Write(p[:room])
+		} else {
+			d.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+func (d *debugBody) String() string {
+	if d.total > int64(d.buf.Len()) {
+		return fmt.Sprintf("%s... (%d bytes total)", d.buf.String(), d.total)
+	}
+	return d.buf.String()
+}
@@ -0,0 +1,335 @@
+package runpod
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+)
+
+// S3StoreOptions configures an S3Store
+type S3StoreOptions struct {
+	// Bucket is the bucket objects are stored in. Required.
+	Bucket string
+
+	// Region is the bucket's region. Defaults to us-east-1.
+	Region string
+
+	// Endpoint is the S3 API URL, such as "http://localhost:9000" for a local
+	// S3-compatible server. Defaults to AWS S3 in Region.
+	Endpoint string
+
+	// PathStyle addresses objects as Endpoint/bucket/key instead of
+	// bucket.endpoint/key. Most S3-compatible servers need it.
+	PathStyle bool
+
+	// AccessKeyID and SecretAccessKey sign requests. Required.
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SessionToken is the token for temporary credentials, if any
+	SessionToken string
+
+	// HTTPClient sends requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// S3Store is an ObjectStore for Amazon S3 and S3-compatible services such as
+// MinIO, Cloudflare R2 or Backblaze B2, signing requests with AWS Signature Version 4
+type S3Store struct {
+	opts     S3StoreOptions
+	endpoint *url.URL
+}
+
+// NewS3Store creates an S3Store, validating its options
+func NewS3Store(opts *S3StoreOptions) (*S3Store, error) {
+	if opts == nil {
+		return nil, NewValidationError("opts", "S3 store options are required")
+	}
+
+	s := &S3Store{opts: *opts}
+	if s.opts.Bucket == "" {
+		return nil, NewValidationError("Bucket", "bucket is required")
+	}
+	if s.opts.AccessKeyID == "" || s.opts.SecretAccessKey == "" {
+		return nil, NewValidationError("AccessKeyID", "access key ID and secret access key are required")
+	}
+	if s.opts.Region == "" {
+		s.opts.Region = "us-east-1"
+	}
+	if s.opts.Endpoint == "" {
+		s.opts.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", s.opts.Region)
+	}
+	if s.opts.HTTPClient == nil {
+		s.opts.HTTPClient = http.DefaultClient
+	}
+
+	endpoint, err := url.Parse(s.opts.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, NewValidationError("Endpoint", fmt.Sprintf("invalid endpoint URL %q", s.opts.Endpoint))
+	}
+	s.endpoint = endpoint
+
+	return s, nil
+}
+
+// Put uploads an object
+func (s *S3Store) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, "PUT", s.objectURL(key).String(), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create S3 request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, sha256Hex(data), time.Now())
+
+	resp, err := s.opts.HTTPClient.Do(req)
+	if err != nil {
+		return NewNetworkError(fmt.Sprintf("failed to upload %s", key), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return s3ResponseError("PUT", key, resp)
+	}
+	io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// Get downloads an object
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 request: %w", err)
+	}
+	s.sign(req, sha256Hex(nil), time.Now())
+
+	resp, err := s.opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil, NewNetworkError(fmt.Sprintf("failed to download %s", key), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, s3ResponseError("GET", key, resp)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, NewNetworkError(fmt.Sprintf("failed to download %s", key), err)
+	}
+	return data, nil
+}
+
+// PresignGet returns a URL anyone can use to download the object until it expires
+func (s *S3Store) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return s.PresignGetAt(key, expires, time.Now())
+}
+
+// PresignGetAt returns a presigned download URL as if signed at the given time
+func (s *S3Store) PresignGetAt(key string, expires time.Duration, at time.Time) (string, error) {
+	seconds := int64(expires / time.Second)
+	if seconds < 1 || seconds > 7*24*60*60 {
+		return "", NewValidationError("expires", "presigned URLs must expire within 1 second to 7 days")
+	}
+
+	at = at.UTC()
+	u := s.objectURL(key)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", s.opts.AccessKeyID+"/"+s.scope(at))
+	query.Set("X-Amz-Date", at.Format(s3TimeFormat))
+	query.Set("X-Amz-Expires", strconv.FormatInt(seconds, 10))
+	query.Set("X-Amz-SignedHeaders", "host")
+	if s.opts.SessionToken != "" {
+		query.Set("X-Amz-Security-Token", s.opts.SessionToken)
+	}
+
+	canonicalRequest := strings.Join([]string{
+		"GET",
+		u.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + u.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	u.RawQuery = canonicalQuery(query) + "&X-Amz-Signature=" + s.signature(canonicalRequest, at)
+
+	return u.String(), nil
+}
+
+// KeyFromURL returns the key of an object in this store's bucket, ignoring
+// any query string, so presigned URLs are recognized too
+func (s *S3Store) KeyFromURL(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != s.endpoint.Scheme {
+		return "", false
+	}
+
+	base := s.objectURL("")
+	if u.Host != base.Host || !strings.HasPrefix(u.Path, base.Path) {
+		return "", false
+	}
+
+	key := strings.TrimPrefix(u.Path, base.Path)
+	return key, key != ""
+}
+
+// objectURL returns the URL of key, which is empty for the bucket itself
+func (s *S3Store) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	basePath := strings.TrimSuffix(u.Path, "/")
+
+	if s.opts.PathStyle {
+		u.Path = basePath + "/" + s.opts.Bucket + "/" + key
+	} else {
+		u.Host = s.opts.Bucket + "." + u.Host
+		u.Path = basePath + "/" + key
+	}
+	u.RawPath = basePath + "/" + s3Escape(strings.TrimPrefix(u.Path, basePath+"/"), true)
+
+	return &u
+}
+
+// sign adds a Signature Version 4 Authorization header to req
+func (s *S3Store) sign(req *http.Request, payloadHash string, at time.Time) {
+	at = at.UTC()
+	req.Header.Set("X-Amz-Date", at.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.opts.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.opts.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.opts.AccessKeyID, s.scope(at), signedHeaders, s.signature(canonicalRequest, at)))
+}
+
+// scope is the credential scope for requests signed at the given time
+func (s *S3Store) scope(at time.Time) string {
+	return at.Format("20060102") + "/" + s.opts.Region + "/s3/aws4_request"
+}
+
+// signature signs a canonical request
+func (s *S3Store) signature(canonicalRequest string, at time.Time) string {
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		at.Format(s3TimeFormat),
+		s.scope(at),
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.opts.SecretAccessKey), at.Format("20060102"))
+	key = hmacSHA256(key, s.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalQuery encodes query parameters sorted by name, as SigV4 requires
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pairs []string
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, s3Escape(name, false)+"="+s3Escape(value, false))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape percent-encodes everything but unreserved characters, and slashes if keepSlash is set
+func s3Escape(s string, keepSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && keepSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3ResponseError builds an error from an S3 error response
+func s3ResponseError(method, key string, resp *http.Response) error {
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if xml.Unmarshal(body, &s3Err) == nil && s3Err.Code != "" {
+		return NewAPIError(resp.StatusCode, fmt.Sprintf("S3 %s %s failed: %s: %s", method, key, s3Err.Code, s3Err.Message))
+	}
+	return NewAPIError(resp.StatusCode, fmt.Sprintf("S3 %s %s failed", method, key))
+}
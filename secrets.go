@@ -3,6 +3,7 @@ package runpod
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // CreateSecret creates a new secret
@@ -61,7 +62,7 @@ func (c *Client) CreateOrUpdateSecret(ctx context.Context, name, value string) e
 	_, err := c.GetSecret(ctx, name)
 	if err != nil {
 		// If not found, create new secret
-		if apiErr, ok := err.(*APIError); ok && apiErr.IsNotFound() {
+		if isNotFoundError(err) {
 			_, createErr := c.CreateSecret(ctx, &CreateSecretRequest{
 				Name:  name,
 				Value: value,
@@ -109,6 +110,15 @@ func (c *Client) ListSecrets(ctx context.Context, opts *ListOptions) ([]*Secret,
 	return response.Secrets, nil
 }
 
+// ListSecretsAll returns an iterator over every secret, following Limit/Offset
+// pagination until the last page. Iteration stops after the first error.
+func (c *Client) ListSecretsAll(ctx context.Context) iter.Seq2[*Secret, error] {
+	fetch := func(limit, offset int) ([]*Secret, error) {
+		return c.ListSecrets(ctx, &ListOptions{Limit: limit, Offset: offset})
+	}
+	return paginate(DefaultPageSize, 0, fetch, func(secret *Secret) string { return secret.Name })
+}
+
 // validateCreateSecretRequest validates a secret creation request
 func (c *Client) validateCreateSecretRequest(req *CreateSecretRequest) error {
 	if req == nil {
@@ -0,0 +1,377 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+)
+
+// RolloutStrategy controls how pods pick up a rotated secret
+type RolloutStrategy string
+
+const (
+	// RolloutRestart restarts each pod in place
+	RolloutRestart RolloutStrategy = "restart"
+
+	// RolloutRecreate creates a replacement pod and terminates the original once
+	// it is running. Spot pods cannot be recreated, since their bid is unknown.
+	RolloutRecreate RolloutStrategy = "recreate"
+)
+
+// SecretRotationOptions configures RotateSecret
+type SecretRotationOptions struct {
+	// Strategy defaults to RolloutRestart
+	Strategy RolloutStrategy
+
+	// BatchSize is how many pods are rolled concurrently. Defaults to 1.
+	BatchSize int
+
+	// WaitAttempts is passed to WaitForPodStatus for each pod. Defaults to 30.
+	WaitAttempts int
+
+	// PreviousValue enables rollback: if a pod fails to return to RUNNING the
+	// secret is set back to this value and the pods rolled so far are restarted.
+	// RunPod never returns secret values, so rollback is impossible without it.
+	PreviousValue string
+}
+
+// PodRollout records a single pod rolled by RotateSecret
+type PodRollout struct {
+	PodID string
+
+	// NewPodID is the replacement pod when using RolloutRecreate
+	NewPodID string
+
+	Err error
+}
+
+// SecretRotationReport describes what RotateSecret changed
+type SecretRotationReport struct {
+	Secret string
+
+	// TemplateIDs are the templates whose env references the secret
+	TemplateIDs []string
+
+	// Rollouts lists every pod that was rolled, in order
+	Rollouts []PodRollout
+
+	// RolledBack is true if the previous value was restored after a failure
+	RolledBack bool
+}
+
+// FindSecretConsumers returns the running pods and the templates whose env
+// references the named secret. Pods started from a referencing template count
+// as consumers even if their own env does not mention the secret.
+func (c *Client) FindSecretConsumers(ctx context.Context, name string) ([]*Pod, []*Template, error) {
+	if err := c.validateRequired("name", name); err != nil {
+		return nil, nil, err
+	}
+
+	templates, err := c.ListTemplates(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var consumers []*Template
+	templateIDs := make(map[string]bool)
+	for _, template := range templates {
+		if referencesSecret(template.Env, name) {
+			consumers = append(consumers, template)
+			templateIDs[template.ID] = true
+		}
+	}
+
+	var pods []*Pod
+	for pod, err := range c.ListPodsAll(ctx, &PodFilter{Status: string(PodStatusRunning)}) {
+		if err != nil {
+			return nil, nil, err
+		}
+		if referencesSecret(pod.Env, name) || templateIDs[pod.TemplateID] {
+			pods = append(pods, pod)
+		}
+	}
+
+	return pods, consumers, nil
+}
+
+// RotateSecret updates a secret's value and rolls every running pod that uses
+// it, in batches, so the new value takes effect. If a pod fails to return to
+// RUNNING and opts.PreviousValue is set, the rotation is rolled back.
+func (c *Client) RotateSecret(ctx context.Context, name, value string, opts *SecretRotationOptions) (*SecretRotationReport, error) {
+	if opts == nil {
+		opts = &SecretRotationOptions{}
+	}
+
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = RolloutRestart
+	}
+	if strategy != RolloutRestart && strategy != RolloutRecreate {
+		return nil, NewValidationErrorWithValue("strategy", "must be either 'restart' or 'recreate'", strategy)
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+
+	pods, templates, err := c.FindSecretConsumers(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// The API does not report a spot pod's bid, so a replacement would come back on-demand
+	if strategy == RolloutRecreate {
+		for _, pod := range pods {
+			if pod.Interruptible {
+				return nil, NewValidationErrorWithValue("strategy", "cannot recreate spot pods, use 'restart'", pod.ID)
+			}
+		}
+	}
+
+	report := &SecretRotationReport{Secret: name}
+	for _, template := range templates {
+		report.TemplateIDs = append(report.TemplateIDs, template.ID)
+	}
+
+	if _, err := c.UpdateSecret(ctx, name, &UpdateSecretRequest{Value: value}); err != nil {
+		return report, err
+	}
+
+	for start := 0; start < len(pods); start += batchSize {
+		end := start + batchSize
+		if end > len(pods) {
+			end = len(pods)
+		}
+
+		batch := c.rollPods(ctx, pods[start:end], templates, strategy, opts.WaitAttempts)
+		report.Rollouts = append(report.Rollouts, batch...)
+
+		var failures []error
+		for _, rollout := range batch {
+			if rollout.Err != nil {
+				failures = append(failures, fmt.Errorf("pod %s: %w", rollout.PodID, rollout.Err))
+			}
+		}
+
+		if len(failures) == 0 {
+			continue
+		}
+
+		rolloutErr := fmt.Errorf("failed to roll out secret %s: %w", name, errors.Join(failures...))
+		if opts.PreviousValue == "" {
+			return report, rolloutErr
+		}
+
+		if err := c.rollbackSecret(ctx, name, opts.PreviousValue, strategy, report.Rollouts, opts.WaitAttempts); err != nil {
+			return report, errors.Join(rolloutErr, fmt.Errorf("rollback failed: %w", err))
+		}
+		report.RolledBack = true
+		return report, rolloutErr
+	}
+
+	return report, nil
+}
+
+// rollPods rolls a batch of pods concurrently
+func (c *Client) rollPods(ctx context.Context, pods []*Pod, templates []*Template, strategy RolloutStrategy, waitAttempts int) []PodRollout {
+	rollouts := make([]PodRollout, len(pods))
+
+	var wg sync.WaitGroup
+	for i, pod := range pods {
+		wg.Add(1)
+		go func(i int, pod *Pod) {
+			defer wg.Done()
+
+			rollout := PodRollout{PodID: pod.ID}
+			if strategy == RolloutRecreate {
+				rollout.NewPodID, rollout.Err = c.recreatePod(ctx, pod, templates, waitAttempts)
+			} else {
+				rollout.Err = c.restartPodAndWait(ctx, pod.ID, waitAttempts)
+			}
+			rollouts[i] = rollout
+		}(i, pod)
+	}
+	wg.Wait()
+
+	return rollouts
+}
+
+// restartPodAndWait restarts a pod and waits for it to start again. The pod's
+// desired status stays RUNNING across a restart, so the new start is detected
+// by lastStartedAt advancing.
+func (c *Client) restartPodAndWait(ctx context.Context, podID string, waitAttempts int) error {
+	pod, err := c.GetPod(ctx, podID)
+	if err != nil {
+		return err
+	}
+	startedBefore := pod.LastStartedAt
+
+	if err := c.RestartPod(ctx, podID); err != nil {
+		return err
+	}
+
+	if waitAttempts <= 0 {
+		waitAttempts = 30
+	}
+	for attempt := 0; attempt < waitAttempts; attempt++ {
+		pod, err := c.GetPod(ctx, podID)
+		if err != nil {
+			return err
+		}
+
+		if isPodInErrorState(pod.DesiredStatus) {
+			return fmt.Errorf("pod %s is in error state after restart: %s", podID, pod.Status())
+		}
+		if pod.DesiredStatus == PodStatusRunning && restartedSince(pod.LastStartedAt, startedBefore) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+		}
+	}
+
+	return fmt.Errorf("pod %s did not restart after %d attempts", podID, waitAttempts)
+}
+
+// restartedSince reports whether a pod's start time moved past before
+func restartedSince(started, before *JSONTime) bool {
+	if started == nil || started.Time.IsZero() {
+		return false
+	}
+	if before == nil || before.Time.IsZero() {
+		return true
+	}
+	return started.Time.After(before.Time)
+}
+
+// recreatePod starts a replacement for pod and terminates the original once the
+// replacement is running. A replacement that never becomes ready is terminated
+// and the original is left untouched.
+func (c *Client) recreatePod(ctx context.Context, pod *Pod, templates []*Template, waitAttempts int) (string, error) {
+	req, err := podCreateRequest(pod)
+	if err != nil {
+		return "", err
+	}
+	restoreSecretRefs(req, templates)
+
+	replacement, err := c.CreatePod(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := c.WaitForPodStatus(ctx, replacement.ID, PodStatusRunning, waitAttempts); err != nil {
+		if termErr := c.TerminatePod(ctx, replacement.ID); termErr != nil {
+			return replacement.ID, errors.Join(err, termErr)
+		}
+		return "", err
+	}
+
+	if err := c.TerminatePod(ctx, pod.ID); err != nil {
+		return replacement.ID, err
+	}
+
+	return replacement.ID, nil
+}
+
+// rollbackSecret restores the previous secret value and restarts every pod
+// that was already rolled onto the new value
+func (c *Client) rollbackSecret(ctx context.Context, name, previous string, strategy RolloutStrategy, rollouts []PodRollout, waitAttempts int) error {
+	if _, err := c.UpdateSecret(ctx, name, &UpdateSecretRequest{Value: previous}); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, rollout := range rollouts {
+		podID := rollout.PodID
+		if strategy == RolloutRecreate {
+			// Without a replacement the original pod was never touched
+			if rollout.NewPodID == "" {
+				continue
+			}
+			podID = rollout.NewPodID
+		}
+
+		if err := c.restartPodAndWait(ctx, podID, waitAttempts); err != nil {
+			errs = append(errs, fmt.Errorf("pod %s: %w", podID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// podCreateRequest builds a request that recreates pod with the same configuration
+func podCreateRequest(pod *Pod) (*CreatePodRequest, error) {
+	if pod.Interruptible {
+		return nil, NewValidationErrorWithValue("interruptible", "spot pods cannot be recreated without their bid", pod.ID)
+	}
+
+	gpuTypeID := pod.GPUTypeID()
+	if gpuTypeID == "" {
+		return nil, NewValidationErrorWithValue("gpuTypeId", "is unknown, cannot recreate pod", pod.ID)
+	}
+
+	gpuCount := pod.GPUCount
+	if gpuCount <= 0 && pod.GPU != nil {
+		gpuCount = pod.GPU.Count
+	}
+
+	req := &CreatePodRequest{
+		Name:              pod.Name,
+		ImageName:         pod.ImageName,
+		GPUTypeIDs:        []string{gpuTypeID},
+		GPUCount:          gpuCount,
+		VCPUCount:         pod.VCPUCount,
+		ContainerDiskInGB: pod.ContainerDiskInGB,
+		VolumeInGB:        pod.VolumeInGB,
+		VolumeMountPath:   pod.VolumeMountPath,
+		Env:               maps.Clone(pod.Env),
+		Ports:             pod.Ports,
+		TemplateID:        pod.TemplateID,
+	}
+	if dataCenterID := pod.DataCenterID(); dataCenterID != "" {
+		req.DataCenterIDs = []string{dataCenterID}
+	}
+
+	return req, nil
+}
+
+// restoreSecretRefs puts the secret references of the pod's template back into
+// the request's env. A pod's env may hold a template's references already
+// resolved to the old values, which would otherwise override the template and
+// pin the replacement to the rotated-out secret.
+func restoreSecretRefs(req *CreatePodRequest, templates []*Template) {
+	if req.TemplateID == "" {
+		return
+	}
+	for _, template := range templates {
+		if template.ID != req.TemplateID {
+			continue
+		}
+		for key, value := range template.Env {
+			if len(SecretRefs(map[string]string{key: value})) == 0 {
+				continue
+			}
+			if req.Env == nil {
+				req.Env = make(map[string]string)
+			}
+			req.Env[key] = value
+		}
+	}
+}
+
+// referencesSecret reports whether any env value references the named secret
+func referencesSecret(env map[string]string, name string) bool {
+	for _, ref := range SecretRefs(env) {
+		if ref == name {
+			return true
+		}
+	}
+	return false
+}
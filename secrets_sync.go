@@ -0,0 +1,185 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// SecretSyncOptions configures SyncSecrets
+type SecretSyncOptions struct {
+	// Prune deletes secrets that are not in the desired set
+	Prune bool
+
+	// PrunePrefix restricts pruning to secrets whose name has this prefix
+	PrunePrefix string
+
+	// SkipExisting leaves secrets that already exist untouched. RunPod never
+	// returns secret values, so by default every existing secret is rewritten.
+	SkipExisting bool
+
+	// DryRun computes the report without changing anything
+	DryRun bool
+}
+
+// SecretSyncReport lists the secret names affected by SyncSecrets. It never holds values.
+type SecretSyncReport struct {
+	Created []string
+	Updated []string
+	Deleted []string
+	Skipped []string
+	DryRun  bool
+}
+
+// String renders the report as one line per action, without secret values
+func (r *SecretSyncReport) String() string {
+	var b strings.Builder
+
+	prefix := ""
+	if r.DryRun {
+		prefix = "(dry run) "
+	}
+
+	for _, group := range []struct {
+		action string
+		names  []string
+	}{
+		{"create", r.Created},
+		{"update", r.Updated},
+		{"delete", r.Deleted},
+		{"skip", r.Skipped},
+	} {
+		for _, name := range group.names {
+			fmt.Fprintf(&b, "%s%s %s\n", prefix, group.action, name)
+		}
+	}
+
+	fmt.Fprintf(&b, "%s%d created, %d updated, %d deleted, %d skipped",
+		prefix, len(r.Created), len(r.Updated), len(r.Deleted), len(r.Skipped))
+
+	return b.String()
+}
+
+// SyncSecrets makes the account's secrets match desired, a map of secret name
+// to value. Secrets missing from the account are created, existing ones are
+// updated, and with opts.Prune secrets absent from desired are deleted.
+// On error the report describes the changes made before the failure.
+func (c *Client) SyncSecrets(ctx context.Context, desired map[string]string, opts *SecretSyncOptions) (*SecretSyncReport, error) {
+	if opts == nil {
+		opts = &SecretSyncOptions{}
+	}
+
+	for name, value := range desired {
+		if err := c.validateRequired("name", name); err != nil {
+			return nil, err
+		}
+		if value == "" {
+			return nil, NewValidationError("value", fmt.Sprintf("cannot be empty for secret %s", name))
+		}
+	}
+
+	var existing []*Secret
+	existingNames := make(map[string]bool)
+	for secret, err := range c.ListSecretsAll(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		existing = append(existing, secret)
+		existingNames[secret.Name] = true
+	}
+
+	report := &SecretSyncReport{DryRun: opts.DryRun}
+
+	for _, name := range sortedKeys(desired) {
+		value := desired[name]
+
+		switch {
+		case !existingNames[name]:
+			if !opts.DryRun {
+				if _, err := c.CreateSecret(ctx, &CreateSecretRequest{Name: name, Value: value}); err != nil {
+					return report, err
+				}
+			}
+			report.Created = append(report.Created, name)
+
+		case opts.SkipExisting:
+			report.Skipped = append(report.Skipped, name)
+
+		default:
+			if !opts.DryRun {
+				if _, err := c.UpdateSecret(ctx, name, &UpdateSecretRequest{Value: value}); err != nil {
+					return report, err
+				}
+			}
+			report.Updated = append(report.Updated, name)
+		}
+	}
+
+	if opts.Prune {
+		for _, secret := range existing {
+			if _, ok := desired[secret.Name]; ok || !strings.HasPrefix(secret.Name, opts.PrunePrefix) {
+				continue
+			}
+
+			if !opts.DryRun {
+				if err := c.DeleteSecret(ctx, secret.Name); err != nil {
+					return report, err
+				}
+			}
+			report.Deleted = append(report.Deleted, secret.Name)
+		}
+	}
+
+	return report, nil
+}
+
+// LoadSecretsFromEnvFile reads secret names and values from one or more .env files.
+// Later files override earlier ones.
+func LoadSecretsFromEnvFile(paths ...string) (map[string]string, error) {
+	secrets, err := godotenv.Read(paths...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+	return secrets, nil
+}
+
+// LoadSecretsFromEnv collects environment variables whose name starts with
+// prefix, using the rest of the variable name as the secret name. The prefix
+// is required so the whole environment, such as PATH and credentials, is never
+// loaded as secrets.
+func LoadSecretsFromEnv(prefix string) (map[string]string, error) {
+	if prefix == "" {
+		return nil, NewValidationError("prefix", "cannot be empty, it would load the whole environment")
+	}
+
+	secrets := make(map[string]string)
+
+	for _, entry := range os.Environ() {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		name := strings.TrimPrefix(key, prefix)
+		if name == "" {
+			continue
+		}
+		secrets[name] = value
+	}
+
+	return secrets, nil
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,72 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+)
+
+// CreateTemplate creates a new pod or serverless template
+func (c *Client) CreateTemplate(ctx context.Context, req *CreateTemplateRequest) (*Template, error) {
+	if err := c.validateCreateTemplateRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Make sure referenced secrets exist before pods are started from the template
+	if err := c.ValidateEnvSecrets(ctx, req.Env); err != nil {
+		return nil, err
+	}
+
+	var template Template
+	err := c.Post(ctx, "/templates", req, &template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+
+	return &template, nil
+}
+
+// GetTemplate retrieves a template by ID
+func (c *Client) GetTemplate(ctx context.Context, templateID string) (*Template, error) {
+	if err := c.validateRequired("templateID", templateID); err != nil {
+		return nil, err
+	}
+
+	var template Template
+	endpoint := fmt.Sprintf("/templates/%s", templateID)
+	err := c.Get(ctx, endpoint, &template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template %s: %w", templateID, err)
+	}
+
+	return &template, nil
+}
+
+// ListTemplates lists the templates owned by the account
+func (c *Client) ListTemplates(ctx context.Context) ([]*Template, error) {
+	var templates []*Template
+	err := c.Get(ctx, "/templates", &templates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// validateCreateTemplateRequest validates a template creation request
+func (c *Client) validateCreateTemplateRequest(req *CreateTemplateRequest) error {
+	if req == nil {
+		return NewValidationError("request", "cannot be nil")
+	}
+
+	if err := c.validateRequired("name", req.Name); err != nil {
+		return err
+	}
+	if err := c.validateRequired("imageName", req.ImageName); err != nil {
+		return err
+	}
+	if err := c.validatePositive("containerDiskInGb", req.ContainerDiskInGB); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package runpod_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// ENDPOINT AUTOSCALER TESTS
+// ================================
+
+func TestAutoscalePolicyDecide(t *testing.T) {
+	policy := runpod.AutoscalePolicy{
+		TargetQueuePerWorker: 2,
+		MinWorkers:           1,
+		MaxWorkers:           10,
+		Headroom:             2,
+		ScaleUpCooldown:      time.Minute,
+		ScaleDownCooldown:    5 * time.Minute,
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		state   runpod.ScaleState
+		health  runpod.EndpointHealth
+		action  runpod.ScaleAction
+		wantMin int
+		wantMax int
+	}{
+		{"scale up for queue", runpod.ScaleState{WorkersMin: 1, WorkersMax: 3}, runpod.EndpointHealth{JobsInQueue: 5, WorkersActive: 2}, runpod.ScaleActionUp, 5, 7},
+		{"at target", runpod.ScaleState{WorkersMin: 5, WorkersMax: 7}, runpod.EndpointHealth{JobsInQueue: 5, WorkersActive: 2}, runpod.ScaleActionNone, 5, 7},
+		{"clamped to max", runpod.ScaleState{WorkersMin: 1, WorkersMax: 3}, runpod.EndpointHealth{JobsInQueue: 100}, runpod.ScaleActionUp, 10, 10},
+		{"idle workers absorb queue", runpod.ScaleState{WorkersMin: 1, WorkersMax: 3}, runpod.EndpointHealth{JobsInQueue: 4, WorkersActive: 2, WorkersIdle: 1}, runpod.ScaleActionUp, 3, 5},
+		{"idle workers cover queue", runpod.ScaleState{WorkersMin: 2, WorkersMax: 4}, runpod.EndpointHealth{JobsInQueue: 4, WorkersActive: 2, WorkersIdle: 3}, runpod.ScaleActionNone, 2, 4},
+		{"idle scales to min", runpod.ScaleState{WorkersMin: 6, WorkersMax: 8}, runpod.EndpointHealth{WorkersIdle: 6}, runpod.ScaleActionDown, 1, 3},
+		{"scale up cooldown", runpod.ScaleState{WorkersMin: 1, WorkersMax: 3, LastScaled: now.Add(-30 * time.Second)}, runpod.EndpointHealth{JobsInQueue: 8}, runpod.ScaleActionNone, 1, 3},
+		{"scale down cooldown", runpod.ScaleState{WorkersMin: 6, WorkersMax: 8, LastScaled: now.Add(-2 * time.Minute)}, runpod.EndpointHealth{}, runpod.ScaleActionNone, 6, 8},
+		{"scale down after cooldown", runpod.ScaleState{WorkersMin: 6, WorkersMax: 8, LastScaled: now.Add(-6 * time.Minute)}, runpod.EndpointHealth{}, runpod.ScaleActionDown, 1, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := policy.Decide(tt.state, &tt.health, now)
+			if decision.Action != tt.action || decision.WorkersMin != tt.wantMin || decision.WorkersMax != tt.wantMax {
+				t.Errorf("Decide() = %s %d-%d (%s), want %s %d-%d",
+					decision.Action, decision.WorkersMin, decision.WorkersMax, decision.Reason, tt.action, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+// endpointServer serves one endpoint's REST resource and serverless health,
+// recording updates
+type endpointServer struct {
+	mu       sync.Mutex
+	endpoint runpod.Endpoint
+	health   runpod.EndpointHealth
+	updates  []map[string]interface{}
+}
+
+func (s *endpointServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.URL.Path == "/v2/endpoint-1/health":
+		json.NewEncoder(w).Encode(s.health)
+	case r.URL.Path == "/endpoints/endpoint-1" && r.Method == "GET":
+		json.NewEncoder(w).Encode(s.endpoint)
+	case r.URL.Path == "/endpoints/endpoint-1" && r.Method == "PATCH":
+		var update map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&update)
+		s.updates = append(s.updates, update)
+		if v, ok := update["workersMin"].(float64); ok {
+			s.endpoint.WorkersMin = int(v)
+		}
+		if v, ok := update["workersMax"].(float64); ok {
+			s.endpoint.WorkersMax = int(v)
+		}
+		json.NewEncoder(w).Encode(s.endpoint)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func createAutoscalerClient(t *testing.T, state *endpointServer) *runpod.Client {
+	t.Helper()
+	server := httptest.NewServer(state)
+	t.Cleanup(server.Close)
+	return runpod.NewClient("test-key", runpod.WithBaseURL(server.URL), runpod.WithServerlessBaseURL(server.URL))
+}
+
+func TestAutoscalerRunOnce(t *testing.T) {
+	state := &endpointServer{
+		endpoint: runpod.Endpoint{ID: "endpoint-1", WorkersMin: 2, WorkersMax: 4},
+		health:   runpod.EndpointHealth{JobsInQueue: 6, WorkersActive: 2},
+	}
+	client := createAutoscalerClient(t, state)
+
+	var audit bytes.Buffer
+	scaler, err := client.NewAutoscaler(runpod.AutoscalerConfig{
+		EndpointID: "endpoint-1",
+		Policy:     runpod.AutoscalePolicy{MaxWorkers: 5, ScaleDownCooldown: time.Nanosecond},
+		AuditLog:   &audit,
+	})
+	if err != nil {
+		t.Fatalf("NewAutoscaler() error = %v", err)
+	}
+
+	event, err := scaler.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	if event.Action != runpod.ScaleActionUp || event.WorkersMin != 5 || event.FromMin != 2 || event.Error != "" {
+		t.Errorf("RunOnce() = %+v, want a scale up to 5", event)
+	}
+
+	// An idle endpoint scales to zero, which must be sent explicitly
+	state.mu.Lock()
+	state.health = runpod.EndpointHealth{WorkersIdle: 5}
+	state.mu.Unlock()
+	time.Sleep(time.Millisecond)
+
+	if event, err := scaler.RunOnce(context.Background()); err != nil || event.Action != runpod.ScaleActionDown {
+		t.Fatalf("RunOnce() = %+v, %v, want a scale down", event, err)
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if len(state.updates) != 2 || state.updates[1]["workersMin"] != float64(0) || state.endpoint.WorkersMax != 0 {
+		t.Errorf("updates = %v, want workersMin and workersMax set to 0", state.updates)
+	}
+
+	samples, err := runpod.ReadHealthSamples(&audit)
+	if err != nil || len(samples) != 2 || samples[0].Health.JobsInQueue != 6 {
+		t.Errorf("ReadHealthSamples(audit log) = %+v, %v", samples, err)
+	}
+}
+
+func TestAutoscalerDryRun(t *testing.T) {
+	state := &endpointServer{
+		endpoint: runpod.Endpoint{ID: "endpoint-1", WorkersMin: 0, WorkersMax: 1},
+		health:   runpod.EndpointHealth{JobsInQueue: 3},
+	}
+	client := createAutoscalerClient(t, state)
+
+	scaler, err := client.NewAutoscaler(runpod.AutoscalerConfig{
+		EndpointID: "endpoint-1",
+		Policy:     runpod.AutoscalePolicy{MaxWorkers: 5},
+		DryRun:     true,
+	})
+	if err != nil {
+		t.Fatalf("NewAutoscaler() error = %v", err)
+	}
+
+	event, err := scaler.RunOnce(context.Background())
+	if err != nil || event.Action != runpod.ScaleActionUp || !event.DryRun {
+		t.Fatalf("RunOnce() = %+v, %v, want a planned scale up", event, err)
+	}
+	if len(state.updates) != 0 {
+		t.Errorf("updates = %v, want none in dry-run mode", state.updates)
+	}
+}
+
+func TestNewAutoscalerValidation(t *testing.T) {
+	client := runpod.NewClient("test-key")
+
+	if _, err := client.NewAutoscaler(runpod.AutoscalerConfig{EndpointID: "endpoint-1"}); !runpod.IsValidationError(err) {
+		t.Errorf("NewAutoscaler() without MaxWorkers error = %v, want a validation error", err)
+	}
+	if _, err := client.NewAutoscaler(runpod.AutoscalerConfig{Policy: runpod.AutoscalePolicy{MaxWorkers: 1}}); !runpod.IsValidationError(err) {
+		t.Errorf("NewAutoscaler() without an endpoint error = %v, want a validation error", err)
+	}
+
+	if _, err := client.UpdateEndpoint(context.Background(), "endpoint-1", &runpod.UpdateEndpointRequest{WorkersMin: 3, WorkersMax: 2}); !runpod.IsValidationError(err) {
+		t.Errorf("UpdateEndpoint() with min > max error = %v, want a validation error", err)
+	}
+}
+
+func TestUpdateEndpointRequestJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		req  runpod.UpdateEndpointRequest
+		want string
+	}{
+		{name: "zero counts left out", req: runpod.UpdateEndpointRequest{Name: "api"}, want: `{"name":"api"}`},
+		{name: "counts sent when set", req: runpod.UpdateEndpointRequest{WorkersMax: 3}, want: `{"workersMax":3}`},
+		{name: "SetWorkers sends zeros", req: runpod.UpdateEndpointRequest{WorkersMax: 3, SetWorkers: true}, want: `{"workersMin":0,"workersMax":3}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.req)
+			if err != nil || string(data) != tt.want {
+				t.Errorf("json.Marshal() = %s, %v, want %s", data, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimulateRecordedSamples(t *testing.T) {
+	recorded := `{"time":"2026-01-01T12:00:00Z","health":{"jobsInQueue":0,"workersActive":0}}
+{"time":"2026-01-01T12:00:30Z","health":{"jobsInQueue":8,"workersActive":0}}
+{"time":"2026-01-01T12:01:00Z","health":{"jobsInQueue":20,"workersActive":4}}
+
+{"time":"2026-01-01T12:01:30Z","health":{"jobsInQueue":30,"workersActive":4}}
+{"time":"2026-01-01T12:03:00Z","health":{"jobsInQueue":0,"workersActive":0}}
+{"time":"2026-01-01T12:09:00Z","health":{"jobsInQueue":0,"workersActive":0}}
+`
+	samples, err := runpod.ReadHealthSamples(strings.NewReader(recorded))
+	if err != nil {
+		t.Fatalf("ReadHealthSamples() error = %v", err)
+	}
+
+	policy := runpod.AutoscalePolicy{TargetQueuePerWorker: 4, MaxWorkers: 8}
+	events, err := runpod.Simulate(policy, runpod.ScaleState{}, samples)
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+
+	var got []string
+	for _, event := range events {
+		got = append(got, fmt.Sprintf("%s:%d", event.Action, event.WorkersMin))
+	}
+	// Up at 12:00:30, up again after the 1 minute cooldown, then down once the 5 minute cooldown passes
+	want := "none:0,up:2,none:2,up:8,none:8,down:0"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Simulate() = %s, want %s", strings.Join(got, ","), want)
+	}
+
+	if _, err := runpod.Simulate(runpod.AutoscalePolicy{}, runpod.ScaleState{}, samples); !runpod.IsValidationError(err) {
+		t.Errorf("Simulate() without MaxWorkers error = %v, want a validation error", err)
+	}
+	if _, err := runpod.ReadHealthSamples(strings.NewReader("not json\n")); err == nil {
+		t.Error("ReadHealthSamples() accepted invalid JSON")
+	}
+}
@@ -0,0 +1,218 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// BATCH RUNNER TESTS
+// ================================
+
+// batchTestServer echoes job inputs, reporting each job IN_PROGRESS once before it completes
+type batchTestServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	inputs      map[string]interface{}
+	polled      map[string]bool
+	submitted   int
+	inFlight    int
+	maxInFlight int
+}
+
+func createBatchTestServer() *batchTestServer {
+	s := &batchTestServer{
+		inputs: map[string]interface{}{"job-existing": "from previous run"},
+		polled: make(map[string]bool),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/run"):
+			var req runpod.RunJobRequest
+			json.NewDecoder(r.Body).Decode(&req)
+
+			s.submitted++
+			s.inFlight++
+			if s.inFlight > s.maxInFlight {
+				s.maxInFlight = s.inFlight
+			}
+
+			id := fmt.Sprintf("job-%d", s.submitted)
+			s.inputs[id] = req.Input
+			json.NewEncoder(w).Encode(runpod.Job{ID: id, Status: "IN_QUEUE"})
+
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/status/"):
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			input, ok := s.inputs[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			if !s.polled[id] {
+				s.polled[id] = true
+				json.NewEncoder(w).Encode(runpod.Job{ID: id, Status: "IN_PROGRESS"})
+				return
+			}
+
+			if id != "job-existing" {
+				s.inFlight--
+			}
+			json.NewEncoder(w).Encode(runpod.Job{ID: id, Status: "COMPLETED", Output: input})
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return s
+}
+
+// readBatchResultsFile returns the final record of each line in a results file
+func readBatchResultsFile(t *testing.T, path string) map[int]runpod.BatchResult {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read results: %v", err)
+	}
+
+	results := make(map[int]runpod.BatchResult)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var result runpod.BatchResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			continue
+		}
+		if result.Status != runpod.BatchStatusSubmitted {
+			results[result.Line] = result
+		}
+	}
+	return results
+}
+
+func TestRunBatchFile(t *testing.T) {
+	server := createBatchTestServer()
+	defer server.Close()
+
+	dir := t.TempDir()
+	inputPath := filepath.Join(dir, "inputs.jsonl")
+	resultsPath := filepath.Join(dir, "results.jsonl")
+
+	var input strings.Builder
+	for i := 1; i <= 6; i++ {
+		fmt.Fprintf(&input, "{\"n\": %d}\n", i)
+	}
+	input.WriteString("\nnot json\n")
+	os.WriteFile(inputPath, []byte(input.String()), 0o644)
+
+	client := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(server.URL))
+	report, err := client.RunBatchFile(context.Background(), "batch", inputPath, resultsPath, &runpod.BatchOptions{
+		Concurrency:  2,
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("RunBatchFile() error = %v", err)
+	}
+
+	want := runpod.BatchReport{Total: 7, Submitted: 6, Completed: 6, Failed: 1}
+	if *report != want {
+		t.Errorf("report = %+v, want %+v", *report, want)
+	}
+	if server.maxInFlight > 2 {
+		t.Errorf("max jobs in flight = %d, want at most 2", server.maxInFlight)
+	}
+
+	results := readBatchResultsFile(t, resultsPath)
+	for line := 1; line <= 6; line++ {
+		output, _ := results[line].Output.(map[string]interface{})
+		if results[line].Status != "COMPLETED" || output["n"] != float64(line) {
+			t.Errorf("line %d result = %+v, want its input echoed", line, results[line])
+		}
+	}
+	if results[8].Status != "FAILED" || results[8].JobID != "" {
+		t.Errorf("invalid line result = %+v, want FAILED without a job", results[8])
+	}
+}
+
+func TestRunBatchFileResume(t *testing.T) {
+	server := createBatchTestServer()
+	defer server.Close()
+
+	dir := t.TempDir()
+	inputPath := filepath.Join(dir, "inputs.jsonl")
+	resultsPath := filepath.Join(dir, "results.jsonl")
+
+	os.WriteFile(inputPath, []byte("{\"n\": 1}\n{\"n\": 2}\n{\"n\": 3}\n{\"n\": 4}\n"), 0o644)
+
+	// Line 1 finished, line 2 was submitted before the crash, line 3 failed and
+	// the crash cut a record for line 4 short
+	previous := `{"line":1,"jobId":"job-old","status":"COMPLETED","output":"done"}
+{"line":2,"jobId":"job-existing","status":"SUBMITTED"}
+{"line":3,"jobId":"job-failed","status":"FAILED","error":"oom"}
+{"line":4,"jobId":"job-`
+	os.WriteFile(resultsPath, []byte(previous), 0o644)
+
+	tests := []struct {
+		name        string
+		retryFailed bool
+		want        runpod.BatchReport
+	}{
+		{
+			name: "skips failed lines",
+			want: runpod.BatchReport{Total: 4, Skipped: 2, Resumed: 1, Submitted: 1, Completed: 2},
+		},
+		{
+			name:        "retries failed lines",
+			retryFailed: true,
+			want:        runpod.BatchReport{Total: 4, Skipped: 3, Submitted: 1, Completed: 1},
+		},
+	}
+
+	client := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(server.URL))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := client.RunBatchFile(context.Background(), "batch", inputPath, resultsPath, &runpod.BatchOptions{
+				PollInterval: time.Millisecond,
+				RetryFailed:  tt.retryFailed,
+			})
+			if err != nil {
+				t.Fatalf("RunBatchFile() error = %v", err)
+			}
+			if *report != tt.want {
+				t.Errorf("report = %+v, want %+v", *report, tt.want)
+			}
+		})
+	}
+
+	results := readBatchResultsFile(t, resultsPath)
+	if results[1].JobID != "job-old" {
+		t.Errorf("line 1 result = %+v, want the original record kept", results[1])
+	}
+	if results[2].JobID != "job-existing" || results[2].Status != "COMPLETED" {
+		t.Errorf("line 2 result = %+v, want the submitted job re-attached", results[2])
+	}
+	if results[3].Status != "COMPLETED" || results[4].Status != "COMPLETED" {
+		t.Errorf("lines 3-4 results = %+v %+v, want them completed", results[3], results[4])
+	}
+	if server.submitted != 2 {
+		t.Errorf("jobs submitted = %d, want 2", server.submitted)
+	}
+}
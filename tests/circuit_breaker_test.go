@@ -0,0 +1,186 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// CIRCUIT BREAKER TESTS
+// ================================
+
+// createFlakyServer fails serverless requests for endpoints listed in failing
+// while healthy is false, and counts the requests it receives
+func createFlakyServer(healthy *atomic.Bool, failing string) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !healthy.Load() && strings.Contains(r.URL.Path, failing) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(runpod.EndpointHealth{})
+	}))
+
+	return server, &requests
+}
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	var healthy atomic.Bool
+	server, requests := createFlakyServer(&healthy, "/v2/")
+	defer server.Close()
+
+	var transitions []string
+	breaker := runpod.NewCircuitBreaker(&runpod.CircuitBreakerOptions{
+		FailureThreshold: 3,
+		OpenTimeout:      50 * time.Millisecond,
+		OnStateChange: func(key string, from, to runpod.CircuitState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	client := runpod.NewClient("test-key",
+		runpod.WithServerlessBaseURL(server.URL),
+		runpod.WithMaxRetryAttempts(0),
+		runpod.WithCircuitBreaker(breaker))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetHealth(ctx, "endpoint-1"); err == nil || runpod.IsCircuitOpenError(err) {
+			t.Fatalf("GetHealth() #%d error = %v, want a server error", i+1, err)
+		}
+	}
+	if got := breaker.State(server.URL); got != runpod.CircuitOpen {
+		t.Fatalf("State() = %s, want open", got)
+	}
+	if breaker.Healthy() {
+		t.Error("Healthy() = true with an open circuit")
+	}
+
+	// Open: fail fast without sending the request
+	_, err := client.GetHealth(ctx, "endpoint-1")
+	if !runpod.IsCircuitOpenError(err) || requests.Load() != 3 {
+		t.Errorf("GetHealth() while open error = %v, requests = %d, want a fast failure", err, requests.Load())
+	}
+
+	// After the timeout a successful probe closes the circuit
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+	if got := breaker.State(server.URL); got != runpod.CircuitHalfOpen {
+		t.Errorf("State() after timeout = %s, want half-open", got)
+	}
+	if _, err := client.GetHealth(ctx, "endpoint-1"); err != nil {
+		t.Fatalf("GetHealth() probe error = %v", err)
+	}
+	if !breaker.Healthy() {
+		t.Errorf("States() = %v, want every circuit closed", breaker.States())
+	}
+
+	want := "closed->open,open->half-open,half-open->closed"
+	if got := strings.Join(transitions, ","); got != want {
+		t.Errorf("transitions = %s, want %s", got, want)
+	}
+}
+
+func TestCircuitBreakerFailedProbeReopens(t *testing.T) {
+	var healthy atomic.Bool
+	server, _ := createFlakyServer(&healthy, "/v2/")
+	defer server.Close()
+
+	breaker := runpod.NewCircuitBreaker(&runpod.CircuitBreakerOptions{FailureThreshold: 1, OpenTimeout: 20 * time.Millisecond})
+	client := runpod.NewClient("test-key",
+		runpod.WithServerlessBaseURL(server.URL),
+		runpod.WithMaxRetryAttempts(0),
+		runpod.WithCircuitBreaker(breaker))
+
+	client.GetHealth(context.Background(), "endpoint-1")
+	time.Sleep(30 * time.Millisecond)
+	if _, err := client.GetHealth(context.Background(), "endpoint-1"); err == nil || runpod.IsCircuitOpenError(err) {
+		t.Errorf("probe error = %v, want the server error", err)
+	}
+	if got := breaker.State(server.URL); got != runpod.CircuitOpen {
+		t.Errorf("State() after a failed probe = %s, want open", got)
+	}
+}
+
+func TestCircuitBreakerPerEndpoint(t *testing.T) {
+	var healthy atomic.Bool
+	server, _ := createFlakyServer(&healthy, "/v2/broken/")
+	defer server.Close()
+
+	breaker := runpod.NewCircuitBreaker(&runpod.CircuitBreakerOptions{FailureThreshold: 2, PerEndpoint: true})
+	client := runpod.NewClient("test-key",
+		runpod.WithServerlessBaseURL(server.URL),
+		runpod.WithMaxRetryAttempts(0),
+		runpod.WithCircuitBreaker(breaker))
+	ctx := context.Background()
+
+	client.GetHealth(ctx, "broken")
+	client.GetHealth(ctx, "broken")
+
+	if _, err := client.GetHealth(ctx, "broken"); !runpod.IsCircuitOpenError(err) {
+		t.Errorf("GetHealth(broken) error = %v, want circuit open", err)
+	}
+	if _, err := client.GetHealth(ctx, "working"); err != nil {
+		t.Errorf("GetHealth(working) error = %v, want success on its own circuit", err)
+	}
+
+	states := breaker.States()
+	if states[server.URL+"/v2/broken"] != runpod.CircuitOpen || states[server.URL+"/v2/working"] != runpod.CircuitClosed {
+		t.Errorf("States() = %v", states)
+	}
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	breaker := runpod.NewCircuitBreaker(&runpod.CircuitBreakerOptions{FailureThreshold: 1})
+	client := runpod.NewClient("test-key", runpod.WithBaseURL(server.URL), runpod.WithCircuitBreaker(breaker))
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetPod(context.Background(), "missing"); runpod.IsCircuitOpenError(err) {
+			t.Fatalf("GetPod() error = %v, 404s should not open the circuit", err)
+		}
+	}
+}
+
+func TestCircuitBreakerIgnoresCredentialErrors(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	breaker := runpod.NewCircuitBreaker(&runpod.CircuitBreakerOptions{FailureThreshold: 1})
+	client := runpod.NewClient("",
+		runpod.WithBaseURL(server.URL),
+		runpod.WithCredentialsProvider(runpod.StaticCredentials("")),
+		runpod.WithCircuitBreaker(breaker),
+	)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetPod(context.Background(), "pod-1")
+		if err == nil || runpod.IsCircuitOpenError(err) {
+			t.Fatalf("GetPod() error = %v, want a credentials error", err)
+		}
+	}
+
+	if !breaker.Healthy() {
+		t.Errorf("breaker states = %v, credential errors should not open the circuit", breaker.States())
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("server received %d requests, want 0", got)
+	}
+}
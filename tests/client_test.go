@@ -0,0 +1,126 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// CLIENT CONFIGURATION TESTS
+// ================================
+
+// countingLogger counts debug lines and is safe for concurrent use
+type countingLogger struct {
+	mu    sync.Mutex
+	lines int
+}
+
+func (l *countingLogger) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines++
+}
+
+func (l *countingLogger) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lines
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	client := runpod.NewClient("test-key-12345", runpod.WithMaxRetryAttempts(5))
+	clone := client.Clone(runpod.WithBaseURL("https://example.com/v1"), runpod.WithTimeout(time.Second))
+
+	if clone.GetBaseURL() != "https://example.com/v1" || clone.GetMaxRetryAttempts() != 5 || clone.GetTimeout() != time.Second {
+		t.Errorf("clone base=%s retries=%d timeout=%v, want the override on top of the parent",
+			clone.GetBaseURL(), clone.GetMaxRetryAttempts(), clone.GetTimeout())
+	}
+	if client.GetBaseURL() != runpod.DefaultBaseURL || client.GetTimeout() != runpod.DefaultTimeout {
+		t.Errorf("parent base=%s timeout=%v, want the defaults", client.GetBaseURL(), client.GetTimeout())
+	}
+
+	clone.SetDebug(true)
+	if client.IsDebugEnabled() || !clone.IsDebugEnabled() {
+		t.Errorf("SetDebug on the clone changed the parent: parent=%v clone=%v", client.IsDebugEnabled(), clone.IsDebugEnabled())
+	}
+}
+
+func TestWithTimeoutDoesNotModifySharedHTTPClient(t *testing.T) {
+	shared := &http.Client{Timeout: time.Minute}
+	client := runpod.NewClient("test-key", runpod.WithHTTPClient(shared), runpod.WithTimeout(time.Second))
+
+	if shared.Timeout != time.Minute {
+		t.Errorf("shared HTTP client timeout = %v, want it unchanged", shared.Timeout)
+	}
+	if client.GetTimeout() != time.Second {
+		t.Errorf("GetTimeout() = %v, want 1s", client.GetTimeout())
+	}
+}
+
+// TestClientConcurrentConfiguration changes settings while requests are in
+// flight. Run with -race to check the configuration is free of data races.
+func TestClientConcurrentConfiguration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(runpod.Pod{ID: "pod-1", DesiredStatus: runpod.PodStatusRunning})
+	}))
+	defer server.Close()
+
+	logger := &countingLogger{}
+	client := runpod.NewClient("test-key", runpod.WithBaseURL(server.URL), runpod.WithLogger(logger))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				c := client
+				switch j % 3 {
+				case 1:
+					c = client.With(runpod.WithRequestHeader("X-Worker", fmt.Sprint(i)))
+				case 2:
+					c = client.Clone(runpod.WithUserAgent("worker"))
+				}
+				if _, err := c.GetPod(context.Background(), "pod-1"); err != nil {
+					errs <- err
+				}
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			client.SetDebug(i%2 == 0)
+			client.SetLogger(logger)
+			_ = client.IsDebugEnabled()
+		}
+	}()
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("GetPod() error = %v", err)
+	}
+
+	client.SetDebug(true)
+	before := logger.count()
+	if _, err := client.GetPod(context.Background(), "pod-1"); err != nil {
+		t.Fatalf("GetPod() error = %v", err)
+	}
+	if logger.count() == before {
+		t.Error("debug logging was not enabled by SetDebug")
+	}
+}
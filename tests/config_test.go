@@ -0,0 +1,160 @@
+package runpod_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// CONFIG PROFILE TESTS
+// ================================
+
+const testConfigFile = `# default profile
+api_key = "rpa_default"
+
+[staging]
+api_key = "rpa_staging" # trailing comment
+base_url = "https://staging.example.com/v1"
+serverless_base_url = 'https://staging.example.com'
+timeout = "45s"
+max_retries = 0
+retry_delay = 2
+debug = true
+
+[profile.ci]
+timeout = 10
+`
+
+// setupConfigEnv points the loader at a config file and clears RUNPOD_* variables
+func setupConfigEnv(t *testing.T, contents string) string {
+	t.Helper()
+
+	for _, name := range []string{
+		"RUNPOD_API_KEY", "RUNPOD_BASE_URL", "RUNPOD_SERVERLESS_BASE_URL", "RUNPOD_TIMEOUT",
+		"RUNPOD_MAX_RETRIES", "RUNPOD_RETRY_DELAY", "RUNPOD_DEBUG", "RUNPOD_PROFILE",
+	} {
+		t.Setenv(name, "")
+	}
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if contents != "" {
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+	}
+	t.Setenv("RUNPOD_CONFIG", path)
+	return path
+}
+
+func TestNewClientFromProfile(t *testing.T) {
+	setupConfigEnv(t, testConfigFile)
+
+	client, err := runpod.NewClientFromProfile("staging")
+	if err != nil {
+		t.Fatalf("NewClientFromProfile() error = %v", err)
+	}
+
+	if client.GetAPIKey() != "rpa_***ging" || client.GetBaseURL() != "https://staging.example.com/v1" ||
+		client.GetServerlessBaseURL() != "https://staging.example.com" {
+		t.Errorf("client key=%s base=%s serverless=%s, want staging settings",
+			client.GetAPIKey(), client.GetBaseURL(), client.GetServerlessBaseURL())
+	}
+	if client.GetTimeout() != 45*time.Second || client.GetMaxRetryAttempts() != 0 ||
+		client.GetRetryDelay() != 2*time.Second || !client.IsDebugEnabled() {
+		t.Errorf("client timeout=%v retries=%d delay=%v debug=%v, want 45s, 0, 2s, true",
+			client.GetTimeout(), client.GetMaxRetryAttempts(), client.GetRetryDelay(), client.IsDebugEnabled())
+	}
+
+	// Explicit options win over the profile
+	client, err = runpod.NewClientFromProfile("staging", runpod.WithDebug(false))
+	if err != nil || client.IsDebugEnabled() {
+		t.Errorf("NewClientFromProfile() with WithDebug(false) debug = %v, %v", client.IsDebugEnabled(), err)
+	}
+}
+
+func TestLoadProfileSelection(t *testing.T) {
+	setupConfigEnv(t, testConfigFile)
+
+	profile, err := runpod.LoadProfile("")
+	if err != nil || profile.APIKey != "rpa_default" {
+		t.Errorf("LoadProfile(\"\") = %+v, %v, want the default profile", profile, err)
+	}
+
+	t.Setenv("RUNPOD_PROFILE", "staging")
+	profile, err = runpod.LoadProfile("")
+	if err != nil || profile.APIKey != "rpa_staging" {
+		t.Errorf("LoadProfile() with RUNPOD_PROFILE = %+v, %v, want staging", profile, err)
+	}
+
+	if _, err := runpod.LoadProfile("missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("LoadProfile(missing) error = %v, want not found", err)
+	}
+}
+
+func TestLoadProfileEnvFallback(t *testing.T) {
+	setupConfigEnv(t, testConfigFile)
+	t.Setenv("RUNPOD_API_KEY", "rpa_env")
+	t.Setenv("RUNPOD_TIMEOUT", "5m")
+	t.Setenv("RUNPOD_DEBUG", "true")
+
+	// The ci profile has no key, so the environment supplies it; its own timeout wins
+	profile, err := runpod.LoadProfile("ci")
+	if err != nil {
+		t.Fatalf("LoadProfile() error = %v", err)
+	}
+	if profile.APIKey != "rpa_env" || profile.Timeout != 10*time.Second || profile.Debug == nil || !*profile.Debug {
+		t.Errorf("profile = %+v, want env key, 10s timeout and debug", profile)
+	}
+}
+
+func TestLoadProfileWithoutConfigFile(t *testing.T) {
+	setupConfigEnv(t, "")
+	t.Setenv("RUNPOD_CONFIG", "")
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := runpod.NewClientFromProfile(""); !runpod.IsValidationError(err) {
+		t.Errorf("NewClientFromProfile() without a key error = %v, want validation error", err)
+	}
+
+	t.Setenv("RUNPOD_API_KEY", "rpa_env_key")
+	t.Setenv("RUNPOD_MAX_RETRIES", "7")
+	client, err := runpod.NewClientFromProfile("")
+	if err != nil {
+		t.Fatalf("NewClientFromProfile() error = %v", err)
+	}
+	if client.GetAPIKey() != "rpa_***_key" || client.GetMaxRetryAttempts() != 7 {
+		t.Errorf("client key=%s retries=%d, want settings from the environment", client.GetAPIKey(), client.GetMaxRetryAttempts())
+	}
+}
+
+func TestLoadProfilesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		wantErr  string
+	}{
+		{name: "unknown key", contents: "api_secret = \"x\"\n", wantErr: ":1: unknown key"},
+		{name: "bad duration", contents: "\n[a]\ntimeout = \"soon\"\n", wantErr: ":3: timeout"},
+		{name: "bad section", contents: "[staging\n", wantErr: "unterminated section"},
+		{name: "missing value", contents: "debug\n", wantErr: "expected key = value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := setupConfigEnv(t, tt.contents)
+			if _, err := runpod.LoadProfiles(path); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadProfiles() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	setupConfigEnv(t, "")
+	if _, err := runpod.LoadProfile(""); err == nil {
+		t.Error("LoadProfile() with a missing RUNPOD_CONFIG file should fail")
+	}
+}
@@ -0,0 +1,158 @@
+package runpod_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// COST REPORT TESTS
+// ================================
+
+func costTestPods(now time.Time) []*runpod.Pod {
+	startedAt := func(ago time.Duration) *runpod.JSONTime {
+		return &runpod.JSONTime{Time: now.Add(-ago)}
+	}
+
+	return []*runpod.Pod{
+		{
+			ID: "pod-1", Name: "trainer-1", DesiredStatus: "RUNNING", ImageName: "pytorch",
+			CostPerHour: 1.00, LastStartedAt: startedAt(2 * time.Hour),
+			GPU: &runpod.PodGPU{ID: "NVIDIA A40"},
+		},
+		{
+			// The adjusted rate wins over the list price
+			ID: "pod-2", Name: "trainer-2", DesiredStatus: "RUNNING", ImageName: "pytorch",
+			CostPerHour: 1.00, AdjustedCostPerHr: 0.50, LastStartedAt: startedAt(4 * time.Hour),
+			GPU: &runpod.PodGPU{ID: "NVIDIA A40"},
+		},
+		{
+			ID: "pod-3", Name: "notebook", DesiredStatus: "RUNNING", ImageName: "jupyter",
+			CostPerHour: 0.25, LastStartedAt: startedAt(30 * time.Minute),
+			Machine: &runpod.PodMachine{GPUTypeID: "NVIDIA RTX 4090"},
+		},
+		{
+			// Stopped pods accrue nothing
+			ID: "pod-4", Name: "trainer-3", DesiredStatus: "EXITED", ImageName: "pytorch",
+			CostPerHour: 2.00, LastStartedAt: startedAt(10 * time.Hour),
+		},
+	}
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestCostReportGrouping(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	pods := costTestPods(now)
+
+	tests := []struct {
+		name       string
+		groupBy    runpod.CostGroupBy
+		wantGroups map[string][2]float64 // key -> accrued, projected daily
+	}{
+		{
+			name:    "name prefix",
+			groupBy: runpod.CostGroupByNamePrefix,
+			wantGroups: map[string][2]float64{
+				"trainer":  {4.00, 36.00},
+				"notebook": {0.125, 6.00},
+			},
+		},
+		{
+			name:    "image",
+			groupBy: runpod.CostGroupByImage,
+			wantGroups: map[string][2]float64{
+				"pytorch": {4.00, 36.00},
+				"jupyter": {0.125, 6.00},
+			},
+		},
+		{
+			name:    "gpu type",
+			groupBy: runpod.CostGroupByGPUType,
+			wantGroups: map[string][2]float64{
+				"NVIDIA A40":      {4.00, 36.00},
+				"NVIDIA RTX 4090": {0.125, 6.00},
+				"(none)":          {0, 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := runpod.NewCostReport(pods, &runpod.CostReportOptions{GroupBy: tt.groupBy}, now)
+
+			if !approxEqual(report.Accrued, 4.125) || !approxEqual(report.ProjectedDaily, 42) || !approxEqual(report.HourlyRate, 1.75) {
+				t.Errorf("totals = %v accrued, %v daily, %v hourly, want 4.125, 42, 1.75",
+					report.Accrued, report.ProjectedDaily, report.HourlyRate)
+			}
+
+			if len(report.Groups) != len(tt.wantGroups) {
+				t.Fatalf("groups = %+v, want %d groups", report.Groups, len(tt.wantGroups))
+			}
+			for _, group := range report.Groups {
+				want, ok := tt.wantGroups[group.Key]
+				if !ok {
+					t.Errorf("unexpected group %q", group.Key)
+					continue
+				}
+				if !approxEqual(group.Accrued, want[0]) || !approxEqual(group.ProjectedDaily, want[1]) {
+					t.Errorf("group %q = %v accrued, %v daily, want %v, %v",
+						group.Key, group.Accrued, group.ProjectedDaily, want[0], want[1])
+				}
+			}
+
+			if report.Groups[0].Accrued < report.Groups[len(report.Groups)-1].Accrued {
+				t.Errorf("groups are not sorted by accrued cost: %+v", report.Groups)
+			}
+		})
+	}
+}
+
+func TestCostReportExport(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	report := runpod.NewCostReport(costTestPods(now), nil, now)
+
+	var csvOut bytes.Buffer
+	if err := report.WriteCSV(&csvOut); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	rows, err := csv.NewReader(&csvOut).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV output does not parse: %v", err)
+	}
+	if len(rows) != 5 || rows[0][0] != "pod_id" {
+		t.Fatalf("CSV rows = %v, want a header and 4 pods", rows)
+	}
+	if rows[2][0] != "pod-2" || rows[2][6] != "0.5000" || rows[2][7] != "4.00" || rows[2][8] != "2.0000" {
+		t.Errorf("pod-2 row = %v, want rate 0.5000, 4.00 hours, 2.0000 accrued", rows[2])
+	}
+
+	var groupsOut bytes.Buffer
+	if err := report.WriteGroupsCSV(&groupsOut); err != nil {
+		t.Fatalf("WriteGroupsCSV() error = %v", err)
+	}
+	groupRows, _ := csv.NewReader(&groupsOut).ReadAll()
+	if len(groupRows) != 3 || groupRows[1][0] != "trainer" || groupRows[1][1] != "3" {
+		t.Errorf("group rows = %v, want trainer first with 3 pods", groupRows)
+	}
+
+	var jsonOut bytes.Buffer
+	if err := report.WriteJSON(&jsonOut); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var decoded runpod.CostReport
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("JSON output does not parse: %v", err)
+	}
+	if len(decoded.Pods) != 4 || !approxEqual(decoded.Accrued, report.Accrued) || decoded.Pods[0].UptimeHours != 2 {
+		t.Errorf("decoded report = %+v, want it to match the original", decoded)
+	}
+}
@@ -0,0 +1,125 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// CREDENTIALS PROVIDER TESTS
+// ================================
+
+// createAuthRecordingServer records the Authorization header of every request
+func createAuthRecordingServer() (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var keys []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"pod-1"}`))
+	}))
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), keys...)
+	}
+}
+
+func TestFileCredentialsRotation(t *testing.T) {
+	server, keys := createAuthRecordingServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "api-key")
+	writeKey := func(key string, modTime time.Time) {
+		if err := os.WriteFile(path, []byte(key+"\n"), 0o600); err != nil {
+			t.Fatalf("failed to write key: %v", err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("failed to set key file time: %v", err)
+		}
+	}
+
+	start := time.Now().Add(-time.Hour)
+	writeKey("rpa_first", start)
+
+	client := runpod.NewClient("", runpod.WithBaseURL(server.URL),
+		runpod.WithCredentialsProvider(runpod.NewFileCredentials(path)))
+
+	ctx := context.Background()
+	client.GetPod(ctx, "pod-1")
+	writeKey("rpa_second", start.Add(time.Minute))
+	client.GetPod(ctx, "pod-1")
+
+	if got := keys(); len(got) != 2 || got[0] != "rpa_first" || got[1] != "rpa_second" {
+		t.Errorf("keys sent = %v, want [rpa_first rpa_second]", got)
+	}
+}
+
+func TestEnvAndChainCredentials(t *testing.T) {
+	server, keys := createAuthRecordingServer()
+	defer server.Close()
+
+	t.Setenv("TEST_RUNPOD_KEY", "")
+	path := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(path, []byte("rpa_from_file"), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	provider := runpod.NewChainCredentials(
+		runpod.EnvCredentials{Name: "TEST_RUNPOD_KEY"},
+		runpod.NewFileCredentials(path),
+	)
+	client := runpod.NewClient("", runpod.WithBaseURL(server.URL), runpod.WithCredentialsProvider(provider))
+
+	ctx := context.Background()
+	client.GetPod(ctx, "pod-1")
+	t.Setenv("TEST_RUNPOD_KEY", "rpa_from_env")
+	client.GetPod(ctx, "pod-1")
+
+	if got := keys(); len(got) != 2 || got[0] != "rpa_from_file" || got[1] != "rpa_from_env" {
+		t.Errorf("keys sent = %v, want the file key, then the env key", got)
+	}
+}
+
+func TestCredentialsProviderError(t *testing.T) {
+	server, keys := createAuthRecordingServer()
+	defer server.Close()
+
+	t.Setenv("TEST_RUNPOD_KEY", "")
+	client := runpod.NewClient("", runpod.WithBaseURL(server.URL),
+		runpod.WithCredentialsProvider(runpod.EnvCredentials{Name: "TEST_RUNPOD_KEY"}))
+
+	_, err := client.GetPod(context.Background(), "pod-1")
+	if !errors.Is(err, runpod.ErrNoCredentials) {
+		t.Errorf("GetPod() error = %v, want ErrNoCredentials", err)
+	}
+	if got := keys(); len(got) != 0 {
+		t.Errorf("requests sent = %d, want none without a key", len(got))
+	}
+	if client.GetAPIKey() != "" {
+		t.Errorf("GetAPIKey() = %q, want empty", client.GetAPIKey())
+	}
+}
+
+func TestNewClientRequiresCredentials(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewClient(\"\") without a credentials provider should panic")
+		}
+	}()
+	runpod.NewClient("")
+}
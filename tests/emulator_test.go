@@ -0,0 +1,309 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+	"github.com/cozy-creator/runpod-go-library/emulator"
+	"github.com/cozy-creator/runpod-go-library/worker"
+)
+
+// ================================
+// SERVERLESS EMULATOR TESTS
+// ================================
+
+// emulatorHandler echoes its input, streams tokens, fails or blocks depending on the mode
+func emulatorHandler(release <-chan struct{}) worker.Handler {
+	return func(ctx context.Context, job *runpod.Job) (interface{}, error) {
+		input := job.Input.(map[string]interface{})
+
+		switch input["mode"] {
+		case "fail":
+			return nil, errors.New("bad input")
+		case "stream":
+			for i := 0; i < 3; i++ {
+				if err := worker.Yield(ctx, fmt.Sprintf("token-%d", i)); err != nil {
+					return nil, err
+				}
+			}
+		case "block":
+			worker.Progress(ctx, "waiting")
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		return map[string]interface{}{"echo": input["mode"]}, nil
+	}
+}
+
+// createEmulatorClient starts an emulator server and a client pointing at it
+func createEmulatorClient(t *testing.T, handler worker.Handler, opts *emulator.Options) *runpod.Client {
+	t.Helper()
+
+	emu := emulator.New(handler, opts)
+	server := httptest.NewServer(emu)
+	t.Cleanup(func() {
+		server.Close()
+		emu.Close()
+	})
+
+	return runpod.NewClient("test-key", runpod.WithServerlessBaseURL(server.URL))
+}
+
+func TestEmulatorRunSync(t *testing.T) {
+	client := createEmulatorClient(t, emulatorHandler(nil), nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		mode       string
+		wantStatus runpod.JobStatus
+		wantError  string
+	}{
+		{name: "completes", mode: "echo", wantStatus: runpod.JobStatusCompleted},
+		{name: "fails", mode: "fail", wantStatus: runpod.JobStatusFailed, wantError: "bad input"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := client.RunSync(ctx, "local", map[string]interface{}{"mode": tt.mode})
+			if err != nil {
+				t.Fatalf("RunSync() error = %v", err)
+			}
+
+			if runpod.JobStatus(job.Status) != tt.wantStatus {
+				t.Errorf("job status = %v, want %v", job.Status, tt.wantStatus)
+			}
+			if job.Error != tt.wantError {
+				t.Errorf("job error = %q, want %q", job.Error, tt.wantError)
+			}
+			if tt.wantStatus == runpod.JobStatusCompleted {
+				output, _ := job.Output.(map[string]interface{})
+				if output["echo"] != tt.mode {
+					t.Errorf("job output = %v, want echo %s", job.Output, tt.mode)
+				}
+			}
+		})
+	}
+}
+
+func TestEmulatorRunAsyncAndStream(t *testing.T) {
+	client := createEmulatorClient(t, emulatorHandler(nil), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := client.RunAsync(ctx, "local", map[string]interface{}{"mode": "stream"})
+	if err != nil {
+		t.Fatalf("RunAsync() error = %v", err)
+	}
+
+	done, err := client.WaitForJobCompletion(ctx, "local", job.ID, 5*time.Second)
+	if err != nil {
+		t.Fatalf("WaitForJobCompletion() error = %v", err)
+	}
+	if done.Status != string(runpod.JobStatusCompleted) {
+		t.Fatalf("job status = %v, want COMPLETED", done.Status)
+	}
+
+	streamed, err := client.StreamResults(ctx, "local", job.ID)
+	if err != nil {
+		t.Fatalf("StreamResults() error = %v", err)
+	}
+	chunks, _ := streamed.Stream.([]interface{})
+	if len(chunks) != 3 {
+		t.Fatalf("stream = %v, want 3 chunks", streamed.Stream)
+	}
+	if first, _ := chunks[0].(map[string]interface{}); first["output"] != "token-0" {
+		t.Errorf("first chunk = %v, want token-0", chunks[0])
+	}
+
+	// Chunks are only delivered once
+	streamed, err = client.StreamResults(ctx, "local", job.ID)
+	if err != nil {
+		t.Fatalf("StreamResults() error = %v", err)
+	}
+	if chunks, _ := streamed.Stream.([]interface{}); len(chunks) != 0 {
+		t.Errorf("second stream = %v, want no chunks", streamed.Stream)
+	}
+}
+
+func TestEmulatorCancelRetryAndPurge(t *testing.T) {
+	release := make(chan struct{})
+	client := createEmulatorClient(t, emulatorHandler(release), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	running, err := client.RunAsync(ctx, "local", map[string]interface{}{"mode": "block"})
+	if err != nil {
+		t.Fatalf("RunAsync() error = %v", err)
+	}
+	queued, err := client.RunAsync(ctx, "local", map[string]interface{}{"mode": "echo"})
+	if err != nil {
+		t.Fatalf("RunAsync() error = %v", err)
+	}
+	purged, err := client.RunAsync(ctx, "local", map[string]interface{}{"mode": "echo"})
+	if err != nil {
+		t.Fatalf("RunAsync() error = %v", err)
+	}
+
+	// Wait for the blocking job to start and report progress
+	for {
+		job, err := client.GetJobStatus(ctx, "local", running.ID)
+		if err != nil {
+			t.Fatalf("GetJobStatus() error = %v", err)
+		}
+		if job.Status == string(runpod.JobStatusInProgress) && job.Output == "waiting" {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	health, err := client.GetHealth(ctx, "local")
+	if err != nil {
+		t.Fatalf("GetHealth() error = %v", err)
+	}
+	if health.JobsInQueue != 2 || health.WorkersActive != 1 {
+		t.Errorf("health = %+v, want 2 queued and 1 active worker", health)
+	}
+
+	if err := client.CancelJob(ctx, "local", queued.ID); err != nil {
+		t.Fatalf("CancelJob() error = %v", err)
+	}
+	if err := client.PurgeQueue(ctx, "local"); err != nil {
+		t.Fatalf("PurgeQueue() error = %v", err)
+	}
+	if err := client.CancelJob(ctx, "local", running.ID); err != nil {
+		t.Fatalf("CancelJob() error = %v", err)
+	}
+
+	for _, id := range []string{running.ID, queued.ID, purged.ID} {
+		job, err := client.GetJobStatus(ctx, "local", id)
+		if err != nil {
+			t.Fatalf("GetJobStatus() error = %v", err)
+		}
+		if job.Status != string(runpod.JobStatusCancelled) {
+			t.Errorf("job %s status = %v, want CANCELLED", id, job.Status)
+		}
+	}
+
+	retried, err := client.RetryJob(ctx, "local", running.ID)
+	if err != nil {
+		t.Fatalf("RetryJob() error = %v", err)
+	}
+	if retried.ID != running.ID || retried.RetryCount != 1 {
+		t.Errorf("retried job = %+v, want same ID with retry count 1", retried)
+	}
+
+	close(release)
+	done, err := client.WaitForJobCompletion(ctx, "local", running.ID, 5*time.Second)
+	if err != nil {
+		t.Fatalf("WaitForJobCompletion() error = %v", err)
+	}
+	if done.Status != string(runpod.JobStatusCompleted) {
+		t.Errorf("retried job status = %v, want COMPLETED", done.Status)
+	}
+}
+
+func TestEmulatorExecutionTimeout(t *testing.T) {
+	client := createEmulatorClient(t, emulatorHandler(nil), &emulator.Options{ExecutionTimeout: 20 * time.Millisecond})
+
+	job, err := client.RunSync(context.Background(), "local", map[string]interface{}{"mode": "block"})
+	if err != nil {
+		t.Fatalf("RunSync() error = %v", err)
+	}
+	if job.Status != string(runpod.JobStatusTimedOut) {
+		t.Errorf("job status = %v, want TIMED_OUT", job.Status)
+	}
+}
+
+func TestEmulatorErrors(t *testing.T) {
+	client := createEmulatorClient(t, emulatorHandler(nil), &emulator.Options{APIKey: "secret"})
+	ctx := context.Background()
+
+	_, err := client.RunSync(ctx, "local", map[string]interface{}{"mode": "echo"})
+	var apiErr *runpod.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsUnauthorized() {
+		t.Errorf("RunSync() with wrong key error = %v, want unauthorized", err)
+	}
+
+	client = createEmulatorClient(t, emulatorHandler(nil), nil)
+	_, err = client.GetJobStatus(ctx, "local", "missing")
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("GetJobStatus() error = %v, want not found error", err)
+	}
+}
+
+func TestEmulatorClose(t *testing.T) {
+	var calls, finished atomic.Int32
+	handler := func(ctx context.Context, job *runpod.Job) (interface{}, error) {
+		calls.Add(1)
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		finished.Add(1)
+		return nil, ctx.Err()
+	}
+
+	emu := emulator.New(handler, nil)
+	server := httptest.NewServer(emu)
+	defer server.Close()
+
+	client := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(server.URL), runpod.WithMaxRetryAttempts(0))
+	ctx := context.Background()
+
+	running, err := client.RunAsync(ctx, "local", map[string]interface{}{"n": 1})
+	if err != nil {
+		t.Fatalf("RunAsync() error = %v", err)
+	}
+	queued, err := client.RunAsync(ctx, "local", map[string]interface{}{"n": 2})
+	if err != nil {
+		t.Fatalf("RunAsync() error = %v", err)
+	}
+	for calls.Load() == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	emu.Close()
+	if finished.Load() != 1 {
+		t.Error("Close() returned before the running handler finished")
+	}
+
+	if job, err := client.GetJobStatus(ctx, "local", queued.ID); err != nil || runpod.JobStatus(job.Status) != runpod.JobStatusCancelled {
+		t.Errorf("queued job = %+v, %v, want CANCELLED", job, err)
+	}
+	if job, err := client.GetJobStatus(ctx, "local", running.ID); err != nil || !isFinalStatus(job.Status) {
+		t.Errorf("running job = %+v, %v, want a final status", job, err)
+	}
+
+	var apiErr *runpod.APIError
+	if _, err := client.RunAsync(ctx, "local", map[string]interface{}{"n": 3}); !errors.As(err, &apiErr) || apiErr.StatusCode != 503 {
+		t.Errorf("RunAsync() after Close error = %v, want 503", err)
+	}
+	if _, err := client.RetryJob(ctx, "local", queued.ID); !errors.As(err, &apiErr) || apiErr.StatusCode != 503 {
+		t.Errorf("RetryJob() after Close error = %v, want 503", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if calls.Load() != 1 {
+		t.Errorf("handler ran %d times, want only the job running at Close", calls.Load())
+	}
+}
+
+// isFinalStatus reports whether a job status string is terminal
+func isFinalStatus(status string) bool {
+	switch runpod.JobStatus(status) {
+	case runpod.JobStatusCompleted, runpod.JobStatusFailed, runpod.JobStatusCancelled, runpod.JobStatusTimedOut:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// SECRET REFERENCE TESTS
+// ================================
+
+func TestEnvBuilder(t *testing.T) {
+	env, err := runpod.NewEnvBuilder().
+		Set("MODEL", "llama").
+		SetSecret("HF_TOKEN", "hf_token").
+		Merge(map[string]string{"AUTH": "Bearer " + runpod.SecretRef("api_key")}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if env["HF_TOKEN"] != "{{ RUNPOD_SECRET_hf_token }}" {
+		t.Errorf("HF_TOKEN = %q, want secret reference", env["HF_TOKEN"])
+	}
+	if env["MODEL"] != "llama" {
+		t.Errorf("MODEL = %q, want llama", env["MODEL"])
+	}
+
+	names := runpod.SecretRefs(env)
+	if strings.Join(names, ",") != "api_key,hf_token" {
+		t.Errorf("SecretRefs() = %v, want [api_key hf_token]", names)
+	}
+
+	if _, err := runpod.NewEnvBuilder().SetSecret("EMPTY", "").Build(); err == nil {
+		t.Errorf("Build() expected error for empty secret name")
+	}
+}
+
+func TestParseSecretRef(t *testing.T) {
+	tests := []struct {
+		value    string
+		wantName string
+		wantOK   bool
+	}{
+		{value: "{{ RUNPOD_SECRET_token }}", wantName: "token", wantOK: true},
+		{value: "{{RUNPOD_SECRET_my-key}}", wantName: "my-key", wantOK: true},
+		{value: "Bearer {{ RUNPOD_SECRET_token }}", wantOK: false},
+		{value: "plain", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			name, ok := runpod.ParseSecretRef(tt.value)
+			if name != tt.wantName || ok != tt.wantOK {
+				t.Errorf("ParseSecretRef() = (%q, %v), want (%q, %v)", name, ok, tt.wantName, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCreatePodValidatesSecretRefs(t *testing.T) {
+	created := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/secrets/exists":
+			json.NewEncoder(w).Encode(runpod.Secret{ID: "s1", Name: "exists"})
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/secrets/"):
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "POST" && r.URL.Path == "/pods":
+			created++
+			json.NewEncoder(w).Encode(runpod.Pod{ID: "pod-1"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr bool
+	}{
+		{name: "existing secret", secret: "exists", wantErr: false},
+		{name: "missing secret", secret: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created = 0
+			env, _ := runpod.NewEnvBuilder().SetSecret("TOKEN", tt.secret).Build()
+
+			req := newFallbackPodRequest()
+			req.Env = env
+
+			_, err := client.CreatePod(ctx, req)
+			if tt.wantErr {
+				if !runpod.IsValidationError(err) {
+					t.Errorf("CreatePod() error = %v, want validation error", err)
+				}
+				if created != 0 {
+					t.Errorf("CreatePod() created a pod despite a missing secret")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CreatePod() error = %v", err)
+			}
+			if created != 1 {
+				t.Errorf("CreatePod() created %d pods, want 1", created)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// GRAPHQL TESTS
+// ================================
+
+// createGraphQLTestServer answers GraphQL requests with the response respond builds
+// from the decoded request
+func createGraphQLTestServer(t *testing.T, respond func(req runpod.GraphQLRequest) (int, interface{})) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/graphql" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("Authorization = %q, want the client's API key", r.Header.Get("Authorization"))
+		}
+
+		var req runpod.GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode GraphQL request: %v", err)
+		}
+
+		status, body := respond(req)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}))
+}
+
+func createGraphQLClient(server *httptest.Server) *runpod.Client {
+	return runpod.NewClient("test-key",
+		runpod.WithGraphQLURL(server.URL+"/graphql"),
+		runpod.WithRetryDelay(time.Millisecond))
+}
+
+func TestGetGPUAvailability(t *testing.T) {
+	server := createGraphQLTestServer(t, func(req runpod.GraphQLRequest) (int, interface{}) {
+		if !strings.Contains(req.Query, "gpuTypes") {
+			t.Errorf("query = %q, want gpuTypes", req.Query)
+		}
+		filter, _ := req.Variables["filter"].(map[string]interface{})
+		lowestPrice, _ := req.Variables["lowestPrice"].(map[string]interface{})
+		if filter["id"] != "NVIDIA A40" || lowestPrice["gpuCount"] != float64(2) || lowestPrice["secureCloud"] != true {
+			t.Errorf("variables = %v, want the A40 filter, 2 GPUs and secure cloud", req.Variables)
+		}
+
+		return http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"gpuTypes": []map[string]interface{}{
+					{
+						"id": "NVIDIA A40", "displayName": "A40", "memoryInGb": 48,
+						"securePrice": 0.79, "secureSpotPrice": 0.39,
+						"lowestPrice": map[string]interface{}{
+							"minimumBidPrice": 0.35, "uninterruptablePrice": 0.79,
+							"stockStatus": "High", "maxUnreservedGpuCount": 8, "availableGpuCounts": []int{1, 2, 4, 8},
+						},
+					},
+					{
+						"id": "NVIDIA H100", "displayName": "H100",
+						"lowestPrice": map[string]interface{}{"stockStatus": nil},
+					},
+				},
+			},
+		}
+	})
+	defer server.Close()
+
+	secure := true
+	gpuTypes, err := createGraphQLClient(server).GetGPUAvailability(context.Background(), &runpod.GPUAvailabilityOptions{
+		GPUTypeID:   "NVIDIA A40",
+		GPUCount:    2,
+		SecureCloud: &secure,
+	})
+	if err != nil {
+		t.Fatalf("GetGPUAvailability() error = %v", err)
+	}
+
+	if len(gpuTypes) != 2 {
+		t.Fatalf("gpuTypes = %+v, want 2", gpuTypes)
+	}
+	a40 := gpuTypes[0]
+	if !a40.Available || a40.SecureSpotPrice != 0.39 || a40.LowestPrice.MinimumBidPrice != 0.35 ||
+		a40.LowestPrice.MaxUnreservedGPUCount != 8 || len(a40.LowestPrice.AvailableGPUCounts) != 4 {
+		t.Errorf("A40 = %+v, lowestPrice = %+v", a40, a40.LowestPrice)
+	}
+	if gpuTypes[1].Available {
+		t.Errorf("H100 without stock should not be available")
+	}
+}
+
+func TestResumeSpotPod(t *testing.T) {
+	server := createGraphQLTestServer(t, func(req runpod.GraphQLRequest) (int, interface{}) {
+		input, _ := req.Variables["input"].(map[string]interface{})
+		if input["podId"] != "pod-1" || input["bidPerGpu"] != 0.4 || input["gpuCount"] != float64(1) {
+			t.Errorf("input = %v, want pod-1 at 0.4 for 1 GPU", input)
+		}
+
+		return http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"podBidResume": map[string]interface{}{"id": "pod-1", "desiredStatus": "RUNNING", "image": "pytorch", "costPerHr": 0.4},
+			},
+		}
+	})
+	defer server.Close()
+
+	client := createGraphQLClient(server)
+	pod, err := client.ResumeSpotPod(context.Background(), "pod-1", 0.4, 1)
+	if err != nil {
+		t.Fatalf("ResumeSpotPod() error = %v", err)
+	}
+	if pod.ID != "pod-1" || pod.Status() != "RUNNING" || pod.ImageName != "pytorch" || pod.CostPerHour != 0.4 {
+		t.Errorf("pod = %+v", pod)
+	}
+
+	if _, err := client.ResumeSpotPod(context.Background(), "pod-1", 0, 1); !runpod.IsValidationError(err) {
+		t.Errorf("ResumeSpotPod() without a bid error = %v, want validation error", err)
+	}
+}
+
+func TestGetAccountInfo(t *testing.T) {
+	server := createGraphQLTestServer(t, func(req runpod.GraphQLRequest) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"myself": map[string]interface{}{"id": "user-1", "balance": 42.5, "currentSpendPerHr": 1.25, "spendLimit": 80},
+			},
+		}
+	})
+	defer server.Close()
+
+	account, err := createGraphQLClient(server).GetAccountInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccountInfo() error = %v", err)
+	}
+	if account.ID != "user-1" || account.Balance != 42.5 || account.CurrentSpendPerHr != 1.25 || account.SpendLimit != 80 {
+		t.Errorf("account = %+v", account)
+	}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   interface{}
+		check  func(err error) bool
+	}{
+		{
+			name:   "errors array",
+			status: http.StatusOK,
+			body: map[string]interface{}{
+				"data":   nil,
+				"errors": []map[string]interface{}{{"message": "bid too low", "extensions": map[string]interface{}{"code": "BAD_USER_INPUT"}}},
+			},
+			check: func(err error) bool {
+				var gqlErr *runpod.GraphQLError
+				return errors.As(err, &gqlErr) && gqlErr.Code() == "BAD_USER_INPUT" && strings.Contains(err.Error(), "bid too low")
+			},
+		},
+		{
+			name:   "unauthenticated",
+			status: http.StatusOK,
+			body: map[string]interface{}{
+				"errors": []map[string]interface{}{{"message": "not logged in", "extensions": map[string]interface{}{"code": "UNAUTHENTICATED"}}},
+			},
+			check: func(err error) bool {
+				var authErr *runpod.AuthError
+				return errors.As(err, &authErr)
+			},
+		},
+		{
+			name:   "http error",
+			status: http.StatusBadRequest,
+			body:   map[string]interface{}{"errors": []map[string]interface{}{{"message": "Syntax Error"}}},
+			check: func(err error) bool {
+				var apiErr *runpod.APIError
+				var gqlErr *runpod.GraphQLError
+				return errors.As(err, &apiErr) && apiErr.IsBadRequest() && strings.Contains(apiErr.Message, "Syntax Error") &&
+					errors.As(err, &gqlErr) && gqlErr.StatusCode == http.StatusBadRequest && gqlErr.Errors[0].Message == "Syntax Error"
+			},
+		},
+		{
+			name:   "http error without errors array",
+			status: http.StatusBadRequest,
+			body:   map[string]interface{}{"message": "bad request"},
+			check: func(err error) bool {
+				var apiErr *runpod.APIError
+				return errors.As(err, &apiErr) && apiErr.IsBadRequest() && !runpod.IsGraphQLError(err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := createGraphQLTestServer(t, func(req runpod.GraphQLRequest) (int, interface{}) {
+				return tt.status, tt.body
+			})
+			defer server.Close()
+
+			var result map[string]interface{}
+			err := createGraphQLClient(server).GraphQL(context.Background(), "query { myself { id } }", nil, &result)
+			if err == nil || !tt.check(err) {
+				t.Errorf("GraphQL() error = %v (%T)", err, err)
+			}
+		})
+	}
+}
+
+func TestGraphQLRetriesServerErrors(t *testing.T) {
+	attempts := 0
+	server := createGraphQLTestServer(t, func(req runpod.GraphQLRequest) (int, interface{}) {
+		attempts++
+		if attempts < 3 {
+			return http.StatusServiceUnavailable, map[string]string{"message": "try again"}
+		}
+		return http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"myself": map[string]string{"id": "user-1"}}}
+	})
+	defer server.Close()
+
+	if _, err := createGraphQLClient(server).GetAccountInfo(context.Background()); err != nil {
+		t.Fatalf("GetAccountInfo() error = %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
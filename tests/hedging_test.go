@@ -0,0 +1,116 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// REQUEST HEDGING TESTS
+// ================================
+
+// createSlowFirstServer stalls the first request until the client gives up on
+// it, reporting the cancellation on cancelled, and answers later ones at once
+func createSlowFirstServer(cancelled chan<- struct{}) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+				cancelled <- struct{}{}
+				return
+			case <-time.After(2 * time.Second):
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(runpod.Pod{ID: "pod-1", DesiredStatus: runpod.PodStatusRunning})
+	}))
+
+	return server, &requests
+}
+
+func TestHedgingWinsOverSlowRequest(t *testing.T) {
+	cancelled := make(chan struct{}, 1)
+	server, requests := createSlowFirstServer(cancelled)
+	defer server.Close()
+
+	client := runpod.NewClient("test-key", runpod.WithBaseURL(server.URL), runpod.WithHedging(20*time.Millisecond))
+
+	start := time.Now()
+	pod, err := client.GetPod(context.Background(), "pod-1")
+	if err != nil || pod.ID != "pod-1" {
+		t.Fatalf("GetPod() = %+v, %v", pod, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetPod() took %v, want the hedge to answer quickly", elapsed)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("requests = %d, want 2", requests.Load())
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("the slow request was not cancelled")
+	}
+
+	if stats := client.GetHedgeStats(); stats != (runpod.HedgeStats{Requests: 1, Hedges: 1, HedgeWins: 1}) {
+		t.Errorf("GetHedgeStats() = %+v, want one winning hedge", stats)
+	}
+}
+
+func TestHedgingSkippedForFastResponses(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(runpod.Job{ID: "job-1", Status: "COMPLETED"})
+	}))
+	defer server.Close()
+
+	client := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(server.URL))
+	hedged := client.With(runpod.WithRequestHedging(time.Second))
+
+	for i := 0; i < 3; i++ {
+		if job, err := hedged.GetJobStatus(context.Background(), "endpoint-1", "job-1"); err != nil || job.ID != "job-1" {
+			t.Fatalf("GetJobStatus() = %+v, %v", job, err)
+		}
+	}
+
+	if requests.Load() != 3 {
+		t.Errorf("requests = %d, want 3 without hedges", requests.Load())
+	}
+	if stats := hedged.GetHedgeStats(); stats != (runpod.HedgeStats{Requests: 3}) {
+		t.Errorf("GetHedgeStats() = %+v, want 3 requests and no hedges", stats)
+	}
+	if stats := client.GetHedgeStats(); stats != (runpod.HedgeStats{}) {
+		t.Errorf("parent GetHedgeStats() = %+v, want hedging disabled", stats)
+	}
+}
+
+func TestHedgingKeepsResponseSizeLimit(t *testing.T) {
+	server := createLargeOutputServer(10_000, true)
+	defer server.Close()
+
+	client := runpod.NewClient("test-key",
+		runpod.WithServerlessBaseURL(server.URL),
+		runpod.WithHedging(time.Second),
+		runpod.WithMaxResponseSize(1000))
+
+	if _, err := client.GetJobStatus(context.Background(), "endpoint-1", "job-1"); !runpod.IsResponseTooLargeError(err) {
+		t.Fatalf("GetJobStatus() error = %v, want ResponseTooLargeError", err)
+	}
+
+	job, err := client.With(runpod.WithRequestMaxResponseSize(1<<20)).GetJobStatus(context.Background(), "endpoint-1", "job-1")
+	if err != nil || job.Status != "COMPLETED" {
+		t.Fatalf("GetJobStatus() with a larger limit = %+v, %v", job, err)
+	}
+}
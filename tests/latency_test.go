@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/cozy-creator/runpod-go-library"
 	"github.com/joho/godotenv"
 )
 
@@ -24,18 +25,6 @@ const (
 )
 
 // GraphQL API structures
-type GraphQLRequest struct {
-	Query     string                 `json:"query"`
-	Variables map[string]interface{} `json:"variables,omitempty"`
-}
-
-type GraphQLResponse struct {
-	Data   interface{} `json:"data"`
-	Errors []struct {
-		Message string `json:"message"`
-	} `json:"errors,omitempty"`
-}
-
 type GraphQLPodResponse struct {
 	Data struct {
 		PodFindAndDeployOnDemand struct {
@@ -96,9 +85,7 @@ type ComparisonResults struct {
 
 // API clients
 type GraphQLClient struct {
-	APIKey  string
-	BaseURL string
-	Client  *http.Client
+	Client *runpod.Client
 }
 
 type RESTClient struct {
@@ -109,9 +96,7 @@ type RESTClient struct {
 
 func NewGraphQLClient(apiKey string) *GraphQLClient {
 	return &GraphQLClient{
-		APIKey:  apiKey,
-		BaseURL: "https://api.runpod.io/graphql",
-		Client:  &http.Client{Timeout: 30 * time.Second},
+		Client: runpod.NewClient(apiKey, runpod.WithMaxRetryAttempts(0)),
 	}
 }
 
@@ -126,19 +111,8 @@ func NewRESTClient(apiKey string) *RESTClient {
 // GraphQL Methods
 func (c *GraphQLClient) CreatePod(ctx context.Context, name string) (*GraphQLPodResponse, error) {
 	query := `
-	mutation {
-		podFindAndDeployOnDemand(input: {
-			cloudType: SECURE
-			gpuCount: 1
-			gpuTypeId: "` + TestGPUType + `"
-			name: "` + name + `"
-			imageName: "` + TestImage + `"
-			containerDiskInGb: 50
-			volumeInGb: 20
-			minVcpuCount: 2
-			minMemoryInGb: 15
-			supportPublicIp: true
-		}) {
+	mutation CreatePod($input: PodFindAndDeployOnDemandInput) {
+		podFindAndDeployOnDemand(input: $input) {
 			id
 			imageName
 			machineId
@@ -146,66 +120,38 @@ func (c *GraphQLClient) CreatePod(ctx context.Context, name string) (*GraphQLPod
 		}
 	}`
 
-	req := GraphQLRequest{Query: query}
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"cloudType":         "SECURE",
+			"gpuCount":          1,
+			"gpuTypeId":         TestGPUType,
+			"name":              name,
+			"imageName":         TestImage,
+			"containerDiskInGb": 50,
+			"volumeInGb":        20,
+			"minVcpuCount":      2,
+			"minMemoryInGb":     15,
+			"supportPublicIp":   true,
+		},
+	}
 
 	var response GraphQLPodResponse
-	err := c.makeRequest(ctx, req, &response)
+	err := c.Client.GraphQL(ctx, query, variables, &response.Data)
 	return &response, err
 }
 
 func (c *GraphQLClient) TerminatePod(ctx context.Context, podID string) error {
 	query := `
-	mutation {
-		podTerminate(input: {
-			podId: "` + podID + `"
-		})
+	mutation TerminatePod($input: PodTerminateInput!) {
+		podTerminate(input: $input)
 	}`
 
-	req := GraphQLRequest{Query: query}
-
-	var response GraphQLTerminateResponse
-	return c.makeRequest(ctx, req, &response)
-}
-
-func (c *GraphQLClient) makeRequest(ctx context.Context, req GraphQLRequest, result interface{}) error {
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"?api_key="+c.APIKey, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.Client.Do(httpReq)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Check for GraphQL errors
-	var gqlResp GraphQLResponse
-	if err := json.Unmarshal(body, &gqlResp); err != nil {
-		return err
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{"podId": podID},
 	}
 
-	if len(gqlResp.Errors) > 0 {
-		return fmt.Errorf("GraphQL error: %s", gqlResp.Errors[0].Message)
-	}
-
-	return json.Unmarshal(body, result)
+	var response GraphQLTerminateResponse
+	return c.Client.GraphQL(ctx, query, variables, &response.Data)
 }
 
 // REST Methods
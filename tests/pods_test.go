@@ -0,0 +1,943 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// POD FALLBACK TESTS
+// ================================
+
+// createFallbackTestServer creates a mock server that only has capacity for
+// the given GPU type and cloud type combination
+func createFallbackTestServer(t *testing.T, gpuTypeID, cloudType string, requests *[]runpod.CreatePodRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/pods" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var req runpod.CreatePodRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		*requests = append(*requests, req)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if req.GPUTypeIDs[0] == "invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid gpu type"})
+			return
+		}
+
+		if req.GPUTypeIDs[0] != gpuTypeID || req.CloudType != cloudType {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error": "There are no longer any instances available with the requested specifications.",
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(runpod.Pod{ID: "pod-123", Name: req.Name, DesiredStatus: "RUNNING"})
+	}))
+}
+
+func newFallbackPodRequest() *runpod.CreatePodRequest {
+	return &runpod.CreatePodRequest{
+		Name:              "fallback-pod",
+		ImageName:         "runpod/pytorch:latest",
+		GPUTypeIDs:        []string{"NVIDIA H100 80GB HBM3"},
+		GPUCount:          1,
+		ContainerDiskInGB: 20,
+	}
+}
+
+func TestCreatePodWithFallback(t *testing.T) {
+	var requests []runpod.CreatePodRequest
+	server := createFallbackTestServer(t, "NVIDIA GeForce RTX 4090", "COMMUNITY", &requests)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL), runpod.WithMaxRetryAttempts(0))
+	req := newFallbackPodRequest()
+
+	result, err := client.CreatePodWithFallback(context.Background(), req, &runpod.PodFallbackOptions{
+		GPUTypeIDs: []string{"NVIDIA H100 80GB HBM3", "NVIDIA GeForce RTX 4090"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePodWithFallback() error = %v", err)
+	}
+
+	if result.Pod == nil || result.Pod.ID != "pod-123" {
+		t.Fatalf("CreatePodWithFallback() pod = %+v, want pod-123", result.Pod)
+	}
+
+	if len(result.Attempts) != 4 {
+		t.Errorf("CreatePodWithFallback() attempts = %d, want 4", len(result.Attempts))
+	}
+
+	if result.Succeeded.GPUTypeID != "NVIDIA GeForce RTX 4090" || result.Succeeded.CloudType != "COMMUNITY" {
+		t.Errorf("CreatePodWithFallback() succeeded = %+v, want RTX 4090 on COMMUNITY", result.Succeeded)
+	}
+
+	for _, attempt := range result.Attempts[:3] {
+		if !runpod.IsNoCapacityError(attempt.Err) {
+			t.Errorf("attempt %+v error should be classified as no capacity", attempt)
+		}
+	}
+
+	// The caller's request must not be modified
+	if len(req.GPUTypeIDs) != 1 || req.CloudType != "" {
+		t.Errorf("CreatePodWithFallback() mutated the base request: %+v", req)
+	}
+}
+
+func TestCreatePodWithFallbackExhausted(t *testing.T) {
+	var requests []runpod.CreatePodRequest
+	server := createFallbackTestServer(t, "none", "SECURE", &requests)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL), runpod.WithMaxRetryAttempts(0))
+
+	result, err := client.CreatePodWithFallback(context.Background(), newFallbackPodRequest(), &runpod.PodFallbackOptions{
+		DataCenterIDs: []string{"US-TX-3", "EU-RO-1"},
+	})
+	if err == nil {
+		t.Fatal("CreatePodWithFallback() expected error but got none")
+	}
+
+	if !runpod.IsNoCapacityError(err) {
+		t.Errorf("CreatePodWithFallback() error = %v, want no capacity error", err)
+	}
+
+	if result.Pod != nil || result.Succeeded != nil {
+		t.Errorf("CreatePodWithFallback() result = %+v, want no pod", result)
+	}
+
+	if len(requests) != 4 {
+		t.Fatalf("server received %d requests, want 4", len(requests))
+	}
+
+	if requests[1].DataCenterIDs[0] != "EU-RO-1" || requests[2].CloudType != "COMMUNITY" {
+		t.Errorf("unexpected attempt order: %+v", requests)
+	}
+}
+
+func TestCreatePodWithFallbackSkipsRetriesForNoCapacity(t *testing.T) {
+	var requests []runpod.CreatePodRequest
+	server := createFallbackTestServer(t, "NVIDIA GeForce RTX 4090", "SECURE", &requests)
+	defer server.Close()
+
+	// Retries are on, but a 500 saying no instances are available must not be retried
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL),
+		runpod.WithMaxRetryAttempts(3), runpod.WithRetryDelay(time.Second))
+
+	start := time.Now()
+	result, err := client.CreatePodWithFallback(context.Background(), newFallbackPodRequest(), &runpod.PodFallbackOptions{
+		GPUTypeIDs: []string{"NVIDIA H100 80GB HBM3", "NVIDIA GeForce RTX 4090"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePodWithFallback() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("CreatePodWithFallback() took %v, want no retry delays", elapsed)
+	}
+	if len(requests) != 3 || !runpod.IsNoCapacityError(result.Attempts[0].Err) {
+		t.Errorf("server received %d requests (attempts %+v), want 3", len(requests), result.Attempts)
+	}
+}
+
+func TestCreatePodWithFallbackStopsOnOtherErrors(t *testing.T) {
+	var requests []runpod.CreatePodRequest
+	server := createFallbackTestServer(t, "NVIDIA GeForce RTX 4090", "SECURE", &requests)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL), runpod.WithMaxRetryAttempts(0))
+
+	_, err := client.CreatePodWithFallback(context.Background(), newFallbackPodRequest(), &runpod.PodFallbackOptions{
+		GPUTypeIDs: []string{"invalid", "NVIDIA GeForce RTX 4090"},
+	})
+	if err == nil {
+		t.Fatal("CreatePodWithFallback() expected error but got none")
+	}
+
+	if runpod.IsNoCapacityError(err) {
+		t.Errorf("CreatePodWithFallback() error = %v, should not be a capacity error", err)
+	}
+
+	if len(requests) != 1 {
+		t.Errorf("server received %d requests, want 1", len(requests))
+	}
+}
+
+// ================================
+// SPOT POD TESTS
+// ================================
+
+// createSpotTestServer creates a mock server whose GraphQL API quotes a minimum bid of 0.20,
+// where pod "spot-preempted" has been preempted and "spot-reclaimed" disappears after one check
+func createSpotTestServer(t *testing.T, requests *[]runpod.CreatePodRequest) *httptest.Server {
+	var reclaimedChecks atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/graphql":
+			var req struct {
+				Variables struct {
+					Filter struct {
+						ID string `json:"id"`
+					} `json:"filter"`
+				} `json:"variables"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			gpuType := runpod.GPUType{ID: req.Variables.Filter.ID, LowestPrice: &runpod.Price{MinimumBidPrice: 0.20}}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"gpuTypes": []runpod.GPUType{gpuType}},
+			})
+
+		case r.Method == "POST" && r.URL.Path == "/pods":
+			var req runpod.CreatePodRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			*requests = append(*requests, req)
+			json.NewEncoder(w).Encode(runpod.Pod{ID: "spot-relaunched", DesiredStatus: "RUNNING", MachineID: "machine-2", Interruptible: true})
+
+		case r.Method == "GET" && r.URL.Path == "/pods/spot-preempted":
+			// Still meant to be running, but its machine was reclaimed
+			json.NewEncoder(w).Encode(runpod.Pod{ID: "spot-preempted", DesiredStatus: "RUNNING", Interruptible: true})
+
+		case r.Method == "GET" && r.URL.Path == "/pods/spot-reclaimed":
+			// Running on its first check, then gone with its machine
+			if reclaimedChecks.Add(1) > 1 {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]string{"error": "pod not found"})
+				return
+			}
+			json.NewEncoder(w).Encode(runpod.Pod{ID: "spot-reclaimed", DesiredStatus: "RUNNING", MachineID: "machine-1", Interruptible: true})
+
+		case r.Method == "GET" && r.URL.Path == "/pods/spot-exited":
+			json.NewEncoder(w).Encode(runpod.Pod{ID: "spot-exited", DesiredStatus: "EXITED", MachineID: "machine-1", Interruptible: true})
+
+		case r.Method == "GET" && r.URL.Path == "/pods/spot-relaunched":
+			json.NewEncoder(w).Encode(runpod.Pod{ID: "spot-relaunched", DesiredStatus: "RUNNING", MachineID: "machine-2", Interruptible: true})
+
+		case r.Method == "DELETE" && r.URL.Path == "/pods/spot-preempted":
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCreateSpotPod(t *testing.T) {
+	var requests []runpod.CreatePodRequest
+	server := createSpotTestServer(t, &requests)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL), runpod.WithGraphQLURL(server.URL+"/graphql"))
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		bid     float64
+		wantErr bool
+	}{
+		{name: "bid above minimum", bid: 0.25, wantErr: false},
+		{name: "bid below minimum", bid: 0.10, wantErr: true},
+		{name: "missing bid", bid: 0, wantErr: true},
+		{name: "negative bid", bid: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			req := newFallbackPodRequest()
+
+			pod, err := client.CreateSpotPod(ctx, req, tt.bid)
+
+			if req.Interruptible || req.BidPerGPU != 0 {
+				t.Errorf("CreateSpotPod() mutated the caller's request: %+v", req)
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("CreateSpotPod() expected error but got none")
+				}
+				if !runpod.IsValidationError(err) {
+					t.Errorf("CreateSpotPod() error = %v, want validation error", err)
+				}
+				if len(requests) != 0 {
+					t.Errorf("CreateSpotPod() sent %d create requests, want 0", len(requests))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CreateSpotPod() error = %v", err)
+			}
+
+			if pod.ID != "spot-relaunched" {
+				t.Errorf("CreateSpotPod() pod ID = %v, want spot-relaunched", pod.ID)
+			}
+
+			if len(requests) != 1 || !requests[0].Interruptible || requests[0].BidPerGPU != tt.bid {
+				t.Errorf("CreateSpotPod() sent %+v, want interruptible with bid %v", requests, tt.bid)
+			}
+		})
+	}
+}
+
+func TestKeepSpotPodRunning(t *testing.T) {
+	var requests []runpod.CreatePodRequest
+	server := createSpotTestServer(t, &requests)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL), runpod.WithGraphQLURL(server.URL+"/graphql"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	var relaunched []string
+	pod, err := client.KeepSpotPodRunning(ctx, "spot-preempted", newFallbackPodRequest(), 0.30, &runpod.SpotRelaunchOptions{
+		PollInterval:       10 * time.Millisecond,
+		TerminatePreempted: true,
+		OnRelaunch: func(preempted, replacement *runpod.Pod) {
+			relaunched = append(relaunched, preempted.ID+"->"+replacement.ID)
+		},
+	})
+	if err != context.DeadlineExceeded {
+		t.Errorf("KeepSpotPodRunning() error = %v, want deadline exceeded", err)
+	}
+
+	if pod == nil || pod.ID != "spot-relaunched" {
+		t.Errorf("KeepSpotPodRunning() pod = %+v, want spot-relaunched", pod)
+	}
+
+	if len(relaunched) != 1 || relaunched[0] != "spot-preempted->spot-relaunched" {
+		t.Errorf("KeepSpotPodRunning() relaunches = %v, want exactly one", relaunched)
+	}
+}
+
+func TestKeepSpotPodRunningRelaunchesVanishedPods(t *testing.T) {
+	var requests []runpod.CreatePodRequest
+	server := createSpotTestServer(t, &requests)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL), runpod.WithGraphQLURL(server.URL+"/graphql"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	pod, err := client.KeepSpotPodRunning(ctx, "spot-reclaimed", newFallbackPodRequest(), 0.30, &runpod.SpotRelaunchOptions{
+		PollInterval: 10 * time.Millisecond,
+	})
+	if err != context.DeadlineExceeded {
+		t.Errorf("KeepSpotPodRunning() error = %v, want deadline exceeded", err)
+	}
+	if pod == nil || pod.ID != "spot-relaunched" || len(requests) != 1 {
+		t.Errorf("KeepSpotPodRunning() pod = %+v, creates = %d, want one relaunch", pod, len(requests))
+	}
+
+	// A pod that was never seen is an error, not a reclaimed pod
+	if _, err := client.KeepSpotPodRunning(ctx, "missing", newFallbackPodRequest(), 0.30, nil); err == nil || len(requests) != 1 {
+		t.Errorf("KeepSpotPodRunning() for a missing pod = %v, creates = %d", err, len(requests))
+	}
+}
+
+func TestKeepSpotPodRunningLeavesExitedPods(t *testing.T) {
+	var requests []runpod.CreatePodRequest
+	server := createSpotTestServer(t, &requests)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL), runpod.WithGraphQLURL(server.URL+"/graphql"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	pod, err := client.KeepSpotPodRunning(ctx, "spot-exited", newFallbackPodRequest(), 0.30, &runpod.SpotRelaunchOptions{
+		PollInterval: 10 * time.Millisecond,
+	})
+	if err != context.DeadlineExceeded {
+		t.Errorf("KeepSpotPodRunning() error = %v, want deadline exceeded", err)
+	}
+	if pod == nil || pod.ID != "spot-exited" || len(requests) != 0 {
+		t.Errorf("KeepSpotPodRunning() pod = %+v, creates = %d, want the exited pod left alone", pod, len(requests))
+	}
+}
+
+func TestCreateSpotPodUnknownGPUType(t *testing.T) {
+	var created int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/graphql" {
+			// GraphQL has no price information for the GPU type
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data":{"gpuTypes":[]}}`))
+			return
+		}
+		if r.Method == "POST" && r.URL.Path == "/pods" {
+			created++
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(runpod.Pod{ID: "spot-new", DesiredStatus: "RUNNING", Interruptible: true})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL), runpod.WithGraphQLURL(server.URL+"/graphql"))
+
+	if _, err := client.CreateSpotPod(context.Background(), newFallbackPodRequest(), 0.25); err != nil || created != 1 {
+		t.Errorf("CreateSpotPod() error = %v, creates = %d, want the bid check skipped", err, created)
+	}
+}
+
+func TestIsSpotPodPreempted(t *testing.T) {
+	tests := []struct {
+		name string
+		pod  *runpod.Pod
+		want bool
+	}{
+		{name: "running spot pod", pod: &runpod.Pod{DesiredStatus: "RUNNING", MachineID: "machine-1", Interruptible: true}, want: false},
+		{name: "spot pod lost its machine", pod: &runpod.Pod{DesiredStatus: "RUNNING", Interruptible: true}, want: true},
+		{name: "new spot pod waiting for a machine", pod: &runpod.Pod{DesiredStatus: "RUNNING", Interruptible: true, CreatedAt: &runpod.JSONTime{Time: time.Now()}}, want: false},
+		{name: "long running spot pod lost its machine", pod: &runpod.Pod{DesiredStatus: "RUNNING", Interruptible: true, CreatedAt: &runpod.JSONTime{Time: time.Now().Add(-time.Hour)}}, want: true},
+		{name: "exited spot pod", pod: &runpod.Pod{DesiredStatus: "EXITED", Interruptible: true}, want: false},
+		{name: "stopped spot pod", pod: &runpod.Pod{DesiredStatus: "STOPPED", Interruptible: true}, want: false},
+		{name: "on-demand pod without machine", pod: &runpod.Pod{DesiredStatus: "RUNNING"}, want: false},
+		{name: "nil pod", pod: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runpod.IsSpotPodPreempted(tt.pod); got != tt.want {
+				t.Errorf("IsSpotPodPreempted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// ================================
+// POD UPDATE TESTS
+// ================================
+
+// createUpdateTestServer creates a mock server holding a single pod "pod-env"
+// and records PATCH bodies and restart calls
+func createUpdateTestServer(t *testing.T, patches *[]map[string]interface{}, restarts *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/pods/pod-env":
+			json.NewEncoder(w).Encode(runpod.Pod{
+				ID:            "pod-env",
+				DesiredStatus: "RUNNING",
+				Env:           map[string]string{"KEEP": "1", "CHANGE": "old", "DROP": "x"},
+			})
+
+		case r.Method == "PATCH" && r.URL.Path == "/pods/pod-env":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			*patches = append(*patches, body)
+			json.NewEncoder(w).Encode(runpod.Pod{ID: "pod-env", DesiredStatus: "RUNNING"})
+
+		case r.Method == "POST" && r.URL.Path == "/pods/pod-env/restart":
+			*restarts++
+			w.WriteHeader(http.StatusOK)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestUpdatePod(t *testing.T) {
+	var patches []map[string]interface{}
+	var restarts int
+	server := createUpdateTestServer(t, &patches, &restarts)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		req      *runpod.UpdatePodRequest
+		wantErr  bool
+		wantBody string
+	}{
+		{
+			name:     "change image and disk",
+			req:      &runpod.UpdatePodRequest{ImageName: "runpod/pytorch:2.2", ContainerDiskInGB: 40},
+			wantBody: `{"containerDiskInGb":40,"imageName":"runpod/pytorch:2.2"}`,
+		},
+		{
+			name:     "clear env",
+			req:      &runpod.UpdatePodRequest{Env: map[string]string{}},
+			wantBody: `{"env":{}}`,
+		},
+		{
+			name:    "empty request",
+			req:     &runpod.UpdatePodRequest{},
+			wantErr: true,
+		},
+		{
+			name:    "negative volume",
+			req:     &runpod.UpdatePodRequest{VolumeInGB: -5},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patches = nil
+			_, err := client.UpdatePod(ctx, "pod-env", tt.req)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("UpdatePod() expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("UpdatePod() error = %v", err)
+			}
+
+			if len(patches) != 1 {
+				t.Fatalf("UpdatePod() sent %d requests, want 1", len(patches))
+			}
+
+			got, _ := json.Marshal(patches[0])
+			if string(got) != tt.wantBody {
+				t.Errorf("UpdatePod() body = %s, want %s", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestUpdatePodEnv(t *testing.T) {
+	var patches []map[string]interface{}
+	var restarts int
+	server := createUpdateTestServer(t, &patches, &restarts)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+
+	_, err := client.UpdatePodEnv(context.Background(), "pod-env",
+		map[string]string{"CHANGE": "new", "ADD": "2"}, []string{"DROP"}, true)
+	if err != nil {
+		t.Fatalf("UpdatePodEnv() error = %v", err)
+	}
+
+	if len(patches) != 1 {
+		t.Fatalf("UpdatePodEnv() sent %d updates, want 1", len(patches))
+	}
+
+	got, _ := json.Marshal(patches[0]["env"])
+	want := `{"ADD":"2","CHANGE":"new","KEEP":"1"}`
+	if string(got) != want {
+		t.Errorf("UpdatePodEnv() env = %s, want %s", got, want)
+	}
+
+	if restarts != 1 {
+		t.Errorf("UpdatePodEnv() restarts = %d, want 1", restarts)
+	}
+}
+
+// ================================
+// POD LOG TAILING TESTS
+// ================================
+
+// createLogsTestServer creates a mock server whose log snapshot for "pod-logs"
+// advances through the given snapshots, one per request
+func createLogsTestServer(snapshots []string) *httptest.Server {
+	var mu sync.Mutex
+	call := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pods/pod-logs/logs" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		mu.Lock()
+		snapshot := snapshots[len(snapshots)-1]
+		if call < len(snapshots) {
+			snapshot = snapshots[call]
+		}
+		call++
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"logs": snapshot})
+	}))
+}
+
+var tailTestSnapshots = []string{
+	"2025-01-01T10:00:00Z boot\n2025-01-01T10:00:01Z loading model\n",
+	"2025-01-01T10:00:01Z loading model\n2025-01-01T10:00:02Z ready\n",
+	"2025-01-01T10:00:02Z ready\n2025-01-01T10:00:03Z request 1\n2025-01-01T10:00:04Z request 2\n",
+}
+
+func TestTailPodLogs(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *runpod.TailLogsOptions
+		want []string
+	}{
+		{
+			name: "all new lines",
+			opts: &runpod.TailLogsOptions{MaxLines: 5},
+			want: []string{"boot", "loading model", "ready", "request 1", "request 2"},
+		},
+		{
+			name: "tail and limit",
+			opts: &runpod.TailLogsOptions{TailLines: 1, MaxLines: 3},
+			want: []string{"loading model", "ready", "request 1"},
+		},
+		{
+			name: "since timestamp",
+			opts: &runpod.TailLogsOptions{Since: time.Date(2025, 1, 1, 10, 0, 2, 0, time.UTC), MaxLines: 3},
+			want: []string{"ready", "request 1", "request 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := createLogsTestServer(tailTestSnapshots)
+			defer server.Close()
+
+			client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			tt.opts.PollInterval = 5 * time.Millisecond
+			lines, errs := client.TailPodLogs(ctx, "pod-logs", tt.opts)
+
+			var got []string
+			for line := range lines {
+				got = append(got, strings.SplitN(line.Text, " ", 2)[1])
+			}
+			if err := <-errs; err != nil {
+				t.Fatalf("TailPodLogs() error = %v", err)
+			}
+
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("TailPodLogs() lines = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPodLogReader(t *testing.T) {
+	server := createLogsTestServer(tailTestSnapshots)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+
+	reader := client.NewPodLogReader(context.Background(), "pod-logs", &runpod.TailLogsOptions{
+		PollInterval: 5 * time.Millisecond,
+		MaxLines:     4,
+	})
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	want := "2025-01-01T10:00:00Z boot\n" +
+		"2025-01-01T10:00:01Z loading model\n" +
+		"2025-01-01T10:00:02Z ready\n" +
+		"2025-01-01T10:00:03Z request 1\n"
+	if string(data) != want {
+		t.Errorf("NewPodLogReader() read %q, want %q", data, want)
+	}
+}
+
+// ================================
+// POD LISTING AND FILTERING TESTS
+// ================================
+
+// createListTestServer creates a mock server holding count pods that honours
+// limit/offset unless ignoreOffset is set, and filters on desiredStatus
+func createListTestServer(count int, ignoreOffset bool, queries *[]string) *httptest.Server {
+	pods := make([]*runpod.Pod, count)
+	for i := range pods {
+		status := runpod.PodStatusRunning
+		if i%2 == 1 {
+			status = runpod.PodStatusExited
+		}
+		pods[i] = &runpod.Pod{
+			ID:            fmt.Sprintf("pod-%03d", i),
+			Name:          fmt.Sprintf("worker-%03d", i),
+			DesiredStatus: status,
+			ImageName:     "runpod/worker:1",
+			Interruptible: i%3 == 0,
+			Env:           map[string]string{"TEAM": []string{"ml", "infra"}[i%2]},
+			GPU:           &runpod.PodGPU{ID: "NVIDIA GeForce RTX 4090", Count: 1},
+		}
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pods" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		*queries = append(*queries, r.URL.RawQuery)
+
+		query := r.URL.Query()
+		var matching []*runpod.Pod
+		for _, pod := range pods {
+			if status := query.Get("desiredStatus"); status != "" && pod.Status() != status {
+				continue
+			}
+			if name := query.Get("name"); name != "" && pod.Name != name {
+				continue
+			}
+			matching = append(matching, pod)
+		}
+
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		if ignoreOffset {
+			offset = 0
+		}
+		if offset > len(matching) {
+			offset = len(matching)
+		}
+		end := len(matching)
+		if limit > 0 && offset+limit < end {
+			end = offset + limit
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"pods": matching[offset:end]})
+	}))
+}
+
+func TestListPodsAll(t *testing.T) {
+	var queries []string
+	server := createListTestServer(250, false, &queries)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	count := 0
+	for pod, err := range client.ListPodsAll(ctx, nil) {
+		if err != nil {
+			t.Fatalf("ListPodsAll() error = %v", err)
+		}
+		if pod.ID != fmt.Sprintf("pod-%03d", count) {
+			t.Fatalf("ListPodsAll() pod %d = %s, out of order", count, pod.ID)
+		}
+		count++
+	}
+
+	if count != 250 {
+		t.Errorf("ListPodsAll() yielded %d pods, want 250", count)
+	}
+	if len(queries) != 3 {
+		t.Errorf("ListPodsAll() made %d requests, want 3", len(queries))
+	}
+
+	// Breaking out of the loop must stop pagination
+	queries = nil
+	for range client.ListPodsAll(ctx, nil) {
+		break
+	}
+	if len(queries) != 1 {
+		t.Errorf("ListPodsAll() made %d requests after break, want 1", len(queries))
+	}
+}
+
+func TestListPodsFiltered(t *testing.T) {
+	var queries []string
+	server := createListTestServer(250, false, &queries)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+	ctx := context.Background()
+	interruptible := true
+
+	tests := []struct {
+		name      string
+		filter    *runpod.PodFilter
+		wantCount int
+		wantQuery string
+	}{
+		{name: "status server-side", filter: &runpod.PodFilter{Status: "running"}, wantCount: 125, wantQuery: "desiredStatus=RUNNING"},
+		{name: "name prefix client-side", filter: &runpod.PodFilter{NamePrefix: "worker-24"}, wantCount: 10},
+		{name: "env label", filter: &runpod.PodFilter{EnvLabels: map[string]string{"TEAM": "infra"}}, wantCount: 125},
+		{name: "interruptible and status", filter: &runpod.PodFilter{Status: "EXITED", Interruptible: &interruptible}, wantCount: 42},
+		{name: "gpu type", filter: &runpod.PodFilter{GPUTypeID: "NVIDIA A100"}, wantCount: 0, wantQuery: "gpuTypeId=NVIDIA+A100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries = nil
+			pods, err := client.ListPodsFiltered(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("ListPodsFiltered() error = %v", err)
+			}
+
+			if len(pods) != tt.wantCount {
+				t.Errorf("ListPodsFiltered() = %d pods, want %d", len(pods), tt.wantCount)
+			}
+
+			if tt.wantQuery != "" && !strings.Contains(queries[0], tt.wantQuery) {
+				t.Errorf("ListPodsFiltered() query = %s, want it to contain %s", queries[0], tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestListPodsBeyondFirstPage(t *testing.T) {
+	var queries []string
+	server := createListTestServer(250, false, &queries)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	running, err := client.ListRunningPods(ctx, &runpod.ListOptions{Limit: 50})
+	if err != nil {
+		t.Fatalf("ListRunningPods() error = %v", err)
+	}
+	if len(running) != 125 {
+		t.Errorf("ListRunningPods() = %d pods, want 125", len(running))
+	}
+
+	pod, err := client.FindPodByName(ctx, "worker-249")
+	if err != nil {
+		t.Fatalf("FindPodByName() error = %v", err)
+	}
+	if pod.ID != "pod-249" {
+		t.Errorf("FindPodByName() = %s, want pod-249", pod.ID)
+	}
+
+	if _, err := client.FindPodByName(ctx, "missing"); err == nil {
+		t.Errorf("FindPodByName() expected error for missing pod")
+	}
+}
+
+func TestListPodsAllIgnoredOffset(t *testing.T) {
+	var queries []string
+	server := createListTestServer(250, true, &queries)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+
+	pods, err := client.ListPodsFiltered(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListPodsFiltered() error = %v", err)
+	}
+
+	if len(pods) != 100 || len(queries) != 2 {
+		t.Errorf("ListPodsFiltered() = %d pods in %d requests, want 100 in 2", len(pods), len(queries))
+	}
+}
+
+// ================================
+// POD STATUS TESTS
+// ================================
+
+func TestPodStatusUnmarshalNormalizesCase(t *testing.T) {
+	var pod runpod.Pod
+	if err := json.Unmarshal([]byte(`{"id":"pod-1","desiredStatus":"running"}`), &pod); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if pod.DesiredStatus != runpod.PodStatusRunning || !pod.DesiredStatus.IsRunning() {
+		t.Errorf("DesiredStatus = %q, want RUNNING", pod.DesiredStatus)
+	}
+
+	data, err := json.Marshal(runpod.Pod{DesiredStatus: "exited"})
+	if err != nil || !strings.Contains(string(data), `"desiredStatus":"EXITED"`) {
+		t.Errorf("Marshal() = %s, %v, want EXITED", data, err)
+	}
+}
+
+func TestPodStatusHelpers(t *testing.T) {
+	tests := []struct {
+		status                       runpod.PodStatus
+		running, stopped, isTerminal bool
+	}{
+		{status: runpod.PodStatusRunning, running: true},
+		{status: runpod.PodStatusExited, stopped: true},
+		{status: runpod.PodStatusPaused, stopped: true},
+		{status: runpod.PodStatusDead, isTerminal: true},
+		{status: runpod.PodStatusTerminated, isTerminal: true},
+		{status: runpod.PodStatusCreated},
+	}
+
+	for _, tt := range tests {
+		if tt.status.IsRunning() != tt.running || tt.status.IsStopped() != tt.stopped || tt.status.IsTerminal() != tt.isTerminal {
+			t.Errorf("%s: IsRunning=%v IsStopped=%v IsTerminal=%v, want %v %v %v", tt.status,
+				tt.status.IsRunning(), tt.status.IsStopped(), tt.status.IsTerminal(), tt.running, tt.stopped, tt.isTerminal)
+		}
+	}
+}
+
+func TestValidatePodTransition(t *testing.T) {
+	tests := []struct {
+		from, to runpod.PodStatus
+		wantErr  bool
+	}{
+		{from: runpod.PodStatusCreated, to: runpod.PodStatusRunning},
+		{from: runpod.PodStatusRunning, to: runpod.PodStatusExited},
+		{from: runpod.PodStatusExited, to: runpod.PodStatusRunning},
+		{from: runpod.PodStatusRunning, to: runpod.PodStatusRunning},
+		{from: runpod.PodStatusDead, to: runpod.PodStatusTerminated},
+		{from: runpod.PodStatusTerminated, to: runpod.PodStatusRunning, wantErr: true},
+		{from: runpod.PodStatusDead, to: runpod.PodStatusRunning, wantErr: true},
+		{from: runpod.PodStatusRunning, to: runpod.PodStatusCreated, wantErr: true},
+		{from: "MIGRATING", to: runpod.PodStatusRunning},
+	}
+
+	for _, tt := range tests {
+		err := runpod.ValidatePodTransition("pod-1", tt.from, tt.to)
+		var transitionErr *runpod.PodTransitionError
+		if got := errors.As(err, &transitionErr); got != tt.wantErr {
+			t.Errorf("ValidatePodTransition(%s, %s) error = %v, want error %v", tt.from, tt.to, err, tt.wantErr)
+		}
+	}
+}
+
+func TestWaitForPodStatusStopsOnTerminalStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"id": "pod-1", "desiredStatus": "terminated"})
+	}))
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+	pod, err := client.WaitForPodStatus(context.Background(), "pod-1", "running", 3)
+	if err == nil || pod == nil || pod.DesiredStatus != runpod.PodStatusTerminated {
+		t.Errorf("WaitForPodStatus() = %+v, %v, want an error for the terminated pod", pod, err)
+	}
+}
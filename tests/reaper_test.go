@@ -0,0 +1,212 @@
+package runpod_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// POD REAPER TESTS
+// ================================
+
+// createReaperTestServer lists pods and records the stop and terminate calls it receives
+func createReaperTestServer(pods []*runpod.Pod) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var actions []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/pods":
+			json.NewEncoder(w).Encode(map[string]interface{}{"pods": pods})
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/stop"),
+			r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/pods/"):
+			if strings.Contains(r.URL.Path, "broken") {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(map[string]string{"message": "machine unavailable"})
+				return
+			}
+			mu.Lock()
+			actions = append(actions, r.Method+" "+r.URL.Path)
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), actions...)
+	}
+}
+
+func reaperTestPods() []*runpod.Pod {
+	ago := func(d time.Duration) *runpod.JSONTime {
+		return &runpod.JSONTime{Time: time.Now().Add(-d)}
+	}
+
+	return []*runpod.Pod{
+		{ID: "pod-overnight", Name: "train-big", DesiredStatus: "RUNNING", LastStartedAt: ago(14 * time.Hour), CreatedAt: ago(14 * time.Hour)},
+		{ID: "pod-fresh", Name: "train-small", DesiredStatus: "RUNNING", LastStartedAt: ago(time.Hour), CreatedAt: ago(time.Hour)},
+		{ID: "pod-scratch", Name: "tmp-debug", DesiredStatus: "RUNNING", LastStartedAt: ago(time.Minute), CreatedAt: ago(time.Minute)},
+		{ID: "pod-quiet", Name: "notebook", DesiredStatus: "RUNNING", LastStartedAt: ago(3 * time.Hour), CreatedAt: ago(40 * 24 * time.Hour)},
+		{ID: "pod-busy", Name: "notebook-2", DesiredStatus: "RUNNING", LastStartedAt: ago(3 * time.Hour), CreatedAt: ago(3 * time.Hour)},
+		{ID: "pod-keep", Name: "prod-inference", DesiredStatus: "RUNNING", LastStartedAt: ago(100 * time.Hour), CreatedAt: ago(100 * time.Hour)},
+		{ID: "pod-broken", Name: "train-broken", DesiredStatus: "RUNNING", LastStartedAt: ago(20 * time.Hour), CreatedAt: ago(20 * time.Hour)},
+	}
+}
+
+func reaperTestRules() []runpod.ReaperRule {
+	return []runpod.ReaperRule{
+		{Name: "scratch", Filter: &runpod.PodFilter{NamePrefix: "tmp-"}, Action: runpod.ReapActionTerminate},
+		{Name: "overnight", Filter: &runpod.PodFilter{NamePrefix: "train-"}, MaxUptime: 12 * time.Hour},
+		{Name: "idle", HeartbeatTimeout: 2 * time.Hour},
+		{Name: "old", MaxLifetime: 30 * 24 * time.Hour, Action: runpod.ReapActionTerminate},
+	}
+}
+
+func TestReaperRunOnce(t *testing.T) {
+	server, actions := createReaperTestServer(reaperTestPods())
+	defer server.Close()
+
+	var audit bytes.Buffer
+	client := runpod.NewClient("test-key", runpod.WithBaseURL(server.URL), runpod.WithRetryDelay(time.Millisecond))
+	reaper, err := client.NewReaper(runpod.ReaperConfig{
+		Rules:     reaperTestRules(),
+		Allowlist: []string{"prod-inference"},
+		AuditLog:  &audit,
+	})
+	if err != nil {
+		t.Fatalf("NewReaper() error = %v", err)
+	}
+	reaper.RecordHeartbeat("pod-busy")
+
+	events, err := reaper.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+
+	wantRules := map[string]string{
+		"pod-overnight": "overnight",
+		"pod-scratch":   "scratch",
+		"pod-quiet":     "idle",
+		"pod-broken":    "overnight",
+	}
+	if len(events) != len(wantRules) {
+		t.Fatalf("events = %+v, want %d", events, len(wantRules))
+	}
+	for _, event := range events {
+		if wantRules[event.PodID] != event.Rule {
+			t.Errorf("pod %s reaped by %q, want %q", event.PodID, event.Rule, wantRules[event.PodID])
+		}
+		if (event.PodID == "pod-broken") != (event.Error != "") {
+			t.Errorf("pod %s event error = %q", event.PodID, event.Error)
+		}
+	}
+
+	wantActions := []string{"POST /pods/pod-overnight/stop", "DELETE /pods/pod-scratch", "POST /pods/pod-quiet/stop"}
+	if got := actions(); strings.Join(got, ",") != strings.Join(wantActions, ",") {
+		t.Errorf("actions = %v, want %v", got, wantActions)
+	}
+
+	lines := strings.Split(strings.TrimSpace(audit.String()), "\n")
+	if len(lines) != len(events) {
+		t.Fatalf("audit log has %d lines, want %d", len(lines), len(events))
+	}
+	var first runpod.ReapEvent
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil || first.PodID != "pod-overnight" || first.Reason == "" {
+		t.Errorf("first audit record = %s, want pod-overnight with a reason", lines[0])
+	}
+}
+
+func TestReaperDryRun(t *testing.T) {
+	server, actions := createReaperTestServer(reaperTestPods())
+	defer server.Close()
+
+	client := runpod.NewClient("test-key", runpod.WithBaseURL(server.URL))
+	reaper, err := client.NewReaper(runpod.ReaperConfig{Rules: reaperTestRules(), DryRun: true})
+	if err != nil {
+		t.Fatalf("NewReaper() error = %v", err)
+	}
+
+	events, err := reaper.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+
+	// Without the allowlist or a heartbeat, prod-inference and notebook-2 are reaped too
+	if len(events) != 6 {
+		t.Errorf("events = %+v, want 6", events)
+	}
+	for _, event := range events {
+		if !event.DryRun || event.Error != "" {
+			t.Errorf("event = %+v, want a dry run without error", event)
+		}
+	}
+	if got := actions(); len(got) != 0 {
+		t.Errorf("actions = %v, want none in dry-run mode", got)
+	}
+}
+
+func TestReaperHeartbeatHandler(t *testing.T) {
+	store := runpod.NewMemoryHeartbeatStore()
+	client := runpod.NewClient("test-key")
+	reaper, err := client.NewReaper(runpod.ReaperConfig{
+		Rules:      []runpod.ReaperRule{{HeartbeatTimeout: time.Hour}},
+		Heartbeats: store,
+	})
+	if err != nil {
+		t.Fatalf("NewReaper() error = %v", err)
+	}
+
+	server := httptest.NewServer(reaper.HeartbeatHandler())
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/heartbeat/pod-1", "application/json", nil)
+	if err != nil {
+		t.Fatalf("heartbeat request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("heartbeat status = %d, want 204", resp.StatusCode)
+	}
+	if at, ok := store.LastHeartbeat("pod-1"); !ok || time.Since(at) > time.Minute {
+		t.Errorf("LastHeartbeat() = %v, %v, want a recent heartbeat", at, ok)
+	}
+}
+
+func TestReaperConfigValidation(t *testing.T) {
+	client := runpod.NewClient("test-key")
+
+	tests := []struct {
+		name string
+		cfg  runpod.ReaperConfig
+	}{
+		{name: "no rules", cfg: runpod.ReaperConfig{}},
+		{name: "bad action", cfg: runpod.ReaperConfig{Rules: []runpod.ReaperRule{{Action: "delete"}}}},
+		{name: "negative limit", cfg: runpod.ReaperConfig{Rules: []runpod.ReaperRule{{MaxUptime: -time.Hour}}}},
+		{name: "no filter or limit", cfg: runpod.ReaperConfig{Rules: []runpod.ReaperRule{{Name: "everything", Action: runpod.ReapActionTerminate}}}},
+		{name: "empty filter", cfg: runpod.ReaperConfig{Rules: []runpod.ReaperRule{{Filter: &runpod.PodFilter{}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.NewReaper(tt.cfg); !runpod.IsValidationError(err) {
+				t.Errorf("NewReaper() error = %v, want validation error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// PER-REQUEST OPTION TESTS
+// ================================
+
+func TestWithRequestHeaderAndBaseURL(t *testing.T) {
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(runpod.Pod{ID: name + ":" + r.Header.Get("X-Trace-Id")})
+		}))
+	}
+	primary, secondary := newServer("primary"), newServer("secondary")
+	defer primary.Close()
+	defer secondary.Close()
+
+	client := runpod.NewClient("test-key", runpod.WithBaseURL(primary.URL))
+	derived := client.With(
+		runpod.WithRequestHeader("X-Trace-Id", "abc"),
+		runpod.WithRequestBaseURL(secondary.URL),
+	)
+
+	pod, err := derived.GetPod(context.Background(), "pod-1")
+	if err != nil || pod.ID != "secondary:abc" {
+		t.Errorf("derived GetPod() = %+v, %v, want secondary:abc", pod, err)
+	}
+
+	// The parent client is unchanged
+	pod, err = client.GetPod(context.Background(), "pod-1")
+	if err != nil || pod.ID != "primary:" {
+		t.Errorf("GetPod() = %+v, %v, want primary without the header", pod, err)
+	}
+	if client.GetBaseURL() != primary.URL {
+		t.Errorf("GetBaseURL() = %s, want %s", client.GetBaseURL(), primary.URL)
+	}
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(runpod.EndpointHealth{})
+	}))
+	defer server.Close()
+
+	client := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(server.URL))
+
+	fast := client.With(runpod.WithRequestTimeout(10*time.Millisecond), runpod.WithRequestRetries(0))
+	if _, err := fast.GetHealth(context.Background(), "endpoint-1"); err == nil {
+		t.Error("GetHealth() with a 10ms timeout should fail")
+	}
+
+	if _, err := client.GetHealth(context.Background(), "endpoint-1"); err != nil {
+		t.Errorf("GetHealth() with the default timeout error = %v", err)
+	}
+	if client.GetTimeout() != runpod.DefaultTimeout {
+		t.Errorf("parent timeout = %v, want %v", client.GetTimeout(), runpod.DefaultTimeout)
+	}
+}
+
+func TestWithRequestRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := runpod.NewClient("test-key",
+		runpod.WithServerlessBaseURL(server.URL),
+		runpod.WithMaxRetryAttempts(2),
+		runpod.WithRetryDelay(time.Millisecond))
+
+	client.With(runpod.WithRequestRetries(0)).GetJobStatus(context.Background(), "endpoint-1", "job-1")
+	if got := atomic.SwapInt32(&attempts, 0); got != 1 {
+		t.Errorf("attempts without retries = %d, want 1", got)
+	}
+
+	client.GetJobStatus(context.Background(), "endpoint-1", "job-1")
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("attempts with the client's retries = %d, want 3", got)
+	}
+}
@@ -0,0 +1,152 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// RESPONSE SIZE AND DECODING TESTS
+// ================================
+
+// createLargeOutputServer answers job requests with an output image of the
+// given size, streamed in chunks when chunked is set so no Content-Length is sent
+func createLargeOutputServer(size int, chunked bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		image := strings.Repeat("A", size)
+		body := fmt.Sprintf(`{"id":"job-1","status":"COMPLETED","output":{"image":"%s"}}`, image)
+
+		if !chunked {
+			w.Write([]byte(body))
+			return
+		}
+		for len(body) > 0 {
+			n := min(len(body), 1024)
+			w.Write([]byte(body[:n]))
+			w.(http.Flusher).Flush()
+			body = body[n:]
+		}
+	}))
+}
+
+func TestMaxResponseSizeContentLength(t *testing.T) {
+	server := createLargeOutputServer(10_000, false)
+	defer server.Close()
+
+	client := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(server.URL), runpod.WithMaxResponseSize(1000))
+
+	_, err := client.GetJobStatus(context.Background(), "endpoint-1", "job-1")
+	if !runpod.IsResponseTooLargeError(err) {
+		t.Fatalf("GetJobStatus() error = %v, want ResponseTooLargeError", err)
+	}
+	if !strings.Contains(err.Error(), "1000 byte limit") {
+		t.Errorf("error = %q, want the limit in the message", err)
+	}
+}
+
+func TestMaxResponseSizeChunked(t *testing.T) {
+	server := createLargeOutputServer(10_000, true)
+	defer server.Close()
+
+	client := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(server.URL), runpod.WithMaxResponseSize(1000))
+
+	if _, err := client.GetJobStatus(context.Background(), "endpoint-1", "job-1"); !runpod.IsResponseTooLargeError(err) {
+		t.Fatalf("GetJobStatus() error = %v, want ResponseTooLargeError", err)
+	}
+
+	// A per-request limit can raise the client's
+	job, err := client.With(runpod.WithRequestMaxResponseSize(1<<20)).GetJobStatus(context.Background(), "endpoint-1", "job-1")
+	if err != nil || job.Status != "COMPLETED" {
+		t.Fatalf("GetJobStatus() with a larger limit = %+v, %v", job, err)
+	}
+}
+
+func TestMaxResponseSizeExactFit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"job-1"}`))
+	}))
+	defer server.Close()
+
+	client := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(server.URL), runpod.WithMaxResponseSize(int64(len(`{"id":"job-1"}`))))
+
+	if job, err := client.GetJobStatus(context.Background(), "endpoint-1", "job-1"); err != nil || job.ID != "job-1" {
+		t.Errorf("GetJobStatus() = %+v, %v, want a body at the limit to succeed", job, err)
+	}
+}
+
+func TestRawJobOutput(t *testing.T) {
+	server := createLargeOutputServer(100, true)
+	defer server.Close()
+
+	client := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(server.URL))
+
+	job, err := client.RunSyncRaw(context.Background(), "endpoint-1", map[string]string{"prompt": "cat"})
+	if err != nil {
+		t.Fatalf("RunSyncRaw() error = %v", err)
+	}
+	if job.ID != "job-1" || job.Status != "COMPLETED" {
+		t.Errorf("RunSyncRaw() = %+v, want the embedded job fields", job.Job)
+	}
+	if !json.Valid(job.Output) || !strings.HasPrefix(string(job.Output), `{"image":"AAAA`) {
+		t.Errorf("Output = %s, want the raw output JSON", job.Output)
+	}
+
+	var output struct {
+		Image string `json:"image"`
+	}
+	if err := job.DecodeOutput(&output); err != nil || len(output.Image) != 100 {
+		t.Errorf("DecodeOutput() = %d bytes, %v", len(output.Image), err)
+	}
+
+	var empty runpod.RawJob
+	if err := empty.DecodeOutput(&output); err == nil {
+		t.Error("DecodeOutput() without output succeeded")
+	}
+}
+
+// recordingLogger keeps every logged line
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestDebugLogsTruncatedBody(t *testing.T) {
+	server := createLargeOutputServer(100_000, true)
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := runpod.NewClient("test-key",
+		runpod.WithServerlessBaseURL(server.URL),
+		runpod.WithDebug(true),
+		runpod.WithLogger(logger))
+
+	if _, err := client.GetJobStatusRaw(context.Background(), "endpoint-1", "job-1"); err != nil {
+		t.Fatalf("GetJobStatusRaw() error = %v", err)
+	}
+
+	for _, line := range logger.lines {
+		if !strings.HasPrefix(line, "[DEBUG] Response Body:") {
+			continue
+		}
+		if len(line) > 10_000 || !strings.Contains(line, "bytes total)") {
+			t.Errorf("body log line is %d bytes, want a truncated prefix", len(line))
+		}
+		return
+	}
+	t.Error("no response body was logged")
+}
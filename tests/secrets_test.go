@@ -0,0 +1,456 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// ================================
+// TEST SETUP AND HELPERS
+// ================================
+
+// mockSecretStore is an in-memory secret store served over the REST routes
+type mockSecretStore struct {
+	mu      sync.Mutex
+	secrets map[string]string
+	writes  int
+}
+
+func newMockSecretStore(secrets map[string]string) *mockSecretStore {
+	return &mockSecretStore{secrets: secrets}
+}
+
+func (s *mockSecretStore) get(name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.secrets[name]
+	return value, ok
+}
+
+// createSecretTestServer creates a mock server backed by store
+func createSecretTestServer(store *mockSecretStore) *httptest.Server {
+	return httptest.NewServer(secretStoreHandler(store))
+}
+
+// secretStoreHandler serves the secret REST routes from store
+func secretStoreHandler(store *mockSecretStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		name := strings.TrimPrefix(r.URL.Path, "/secrets/")
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/secrets":
+			var secrets []runpod.Secret
+			for name := range store.secrets {
+				secrets = append(secrets, runpod.Secret{ID: "id-" + name, Name: name})
+			}
+			sort.Slice(secrets, func(i, j int) bool { return secrets[i].Name < secrets[j].Name })
+			json.NewEncoder(w).Encode(map[string]interface{}{"secrets": secrets})
+
+		case r.Method == "POST" && r.URL.Path == "/secrets":
+			var req runpod.CreateSecretRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			store.secrets[req.Name] = req.Value
+			store.writes++
+			json.NewEncoder(w).Encode(runpod.Secret{ID: "id-" + req.Name, Name: req.Name})
+
+		case r.Method == "GET":
+			if _, ok := store.secrets[name]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]string{"error": "secret not found"})
+				return
+			}
+			json.NewEncoder(w).Encode(runpod.Secret{ID: "id-" + name, Name: name})
+
+		case r.Method == "PUT":
+			var req runpod.UpdateSecretRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			store.secrets[name] = req.Value
+			store.writes++
+			json.NewEncoder(w).Encode(runpod.Secret{ID: "id-" + name, Name: name})
+
+		case r.Method == "DELETE":
+			delete(store.secrets, name)
+			store.writes++
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+// ================================
+// SECRET SYNC TESTS
+// ================================
+
+func TestSyncSecrets(t *testing.T) {
+	store := newMockSecretStore(map[string]string{
+		"APP_TOKEN":  "old-token",
+		"APP_STALE":  "stale",
+		"OTHER_KEEP": "keep",
+	})
+	server := createSecretTestServer(store)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+	desired := map[string]string{
+		"APP_TOKEN": "new-token",
+		"APP_NEW":   "super-secret-value",
+	}
+
+	report, err := client.SyncSecrets(context.Background(), desired, &runpod.SecretSyncOptions{
+		Prune:       true,
+		PrunePrefix: "APP_",
+	})
+	if err != nil {
+		t.Fatalf("SyncSecrets() error = %v", err)
+	}
+
+	if strings.Join(report.Created, ",") != "APP_NEW" ||
+		strings.Join(report.Updated, ",") != "APP_TOKEN" ||
+		strings.Join(report.Deleted, ",") != "APP_STALE" {
+		t.Errorf("SyncSecrets() report = %+v", report)
+	}
+
+	if value, _ := store.get("APP_TOKEN"); value != "new-token" {
+		t.Errorf("APP_TOKEN = %q, want new-token", value)
+	}
+	if _, ok := store.get("APP_STALE"); ok {
+		t.Errorf("APP_STALE should have been pruned")
+	}
+	if _, ok := store.get("OTHER_KEEP"); !ok {
+		t.Errorf("OTHER_KEEP is outside the prune prefix and should be kept")
+	}
+}
+
+func TestSyncSecretsDryRun(t *testing.T) {
+	store := newMockSecretStore(map[string]string{"APP_TOKEN": "old-token", "APP_STALE": "stale"})
+	server := createSecretTestServer(store)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+
+	report, err := client.SyncSecrets(context.Background(), map[string]string{
+		"APP_TOKEN": "new-token",
+		"APP_NEW":   "super-secret-value",
+	}, &runpod.SecretSyncOptions{Prune: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("SyncSecrets() error = %v", err)
+	}
+
+	if store.writes != 0 {
+		t.Errorf("SyncSecrets() dry run made %d writes, want 0", store.writes)
+	}
+
+	text := report.String()
+	if strings.Contains(text, "new-token") || strings.Contains(text, "super-secret-value") {
+		t.Errorf("SyncSecretsReport.String() leaked a secret value: %s", text)
+	}
+	if !strings.Contains(text, "(dry run) create APP_NEW") || !strings.Contains(text, "(dry run) delete APP_STALE") {
+		t.Errorf("SyncSecretsReport.String() = %s", text)
+	}
+}
+
+func TestLoadSecretsFromEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	if err := os.WriteFile(path, []byte("# comment\nAPI_TOKEN=abc123\nQUOTED=\"hello world\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	secrets, err := runpod.LoadSecretsFromEnvFile(path)
+	if err != nil {
+		t.Fatalf("LoadSecretsFromEnvFile() error = %v", err)
+	}
+
+	if secrets["API_TOKEN"] != "abc123" || secrets["QUOTED"] != "hello world" || len(secrets) != 2 {
+		t.Errorf("LoadSecretsFromEnvFile() = %v", secrets)
+	}
+}
+
+func TestLoadSecretsFromEnv(t *testing.T) {
+	t.Setenv("SYNCTEST_SECRET_DB_PASSWORD", "pw")
+	t.Setenv("SYNCTEST_SECRET_", "ignored")
+	t.Setenv("SYNCTEST_OTHER", "ignored")
+
+	secrets, err := runpod.LoadSecretsFromEnv("SYNCTEST_SECRET_")
+	if err != nil || len(secrets) != 1 || secrets["DB_PASSWORD"] != "pw" {
+		t.Errorf("LoadSecretsFromEnv() = %v, %v", secrets, err)
+	}
+
+	if _, err := runpod.LoadSecretsFromEnv(""); !runpod.IsValidationError(err) {
+		t.Errorf("LoadSecretsFromEnv(\"\") error = %v, want a validation error", err)
+	}
+}
+
+func TestCreateOrUpdateSecretCreatesMissing(t *testing.T) {
+	store := newMockSecretStore(map[string]string{})
+	server := createSecretTestServer(store)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+
+	if err := client.CreateOrUpdateSecret(context.Background(), "NEW_SECRET", "value"); err != nil {
+		t.Fatalf("CreateOrUpdateSecret() error = %v", err)
+	}
+
+	if value, ok := store.get("NEW_SECRET"); !ok || value != "value" {
+		t.Errorf("NEW_SECRET = %q, want value", value)
+	}
+}
+
+// ================================
+// SECRET ROTATION TESTS
+// ================================
+
+// rotationTestServer records the pods created and terminated during a rotation
+type rotationTestServer struct {
+	*httptest.Server
+	created    []runpod.CreatePodRequest
+	terminated []string
+}
+
+// createRotationTestServer serves the secret store plus three running pods:
+// pod-env references the secret directly, pod-template through its template
+// (its own env holds the resolved value), and pod-other does not use it. Each
+// restart advances a pod's lastStartedAt. Pods listed in failOnce come back
+// EXITED after their first restart and RUNNING after later ones.
+func createRotationTestServer(store *mockSecretStore, failOnce map[string]bool, restarts map[string]int) *rotationTestServer {
+	secrets := secretStoreHandler(store)
+	var mu sync.Mutex
+	started := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	pods := []runpod.Pod{
+		{ID: "pod-env", Name: "env", ImageName: "app:1", ContainerDiskInGB: 10, DesiredStatus: "RUNNING", Env: map[string]string{"TOKEN": runpod.SecretRef("api_token")},
+			GPU: &runpod.PodGPU{ID: "NVIDIA A40", Count: 1}},
+		{ID: "pod-template", Name: "template", ImageName: "app:1", ContainerDiskInGB: 10, DesiredStatus: "RUNNING", TemplateID: "tpl-1", Env: map[string]string{"TOKEN": "old", "MODE": "prod"},
+			GPU: &runpod.PodGPU{ID: "NVIDIA A40", Count: 1}},
+		{ID: "pod-other", DesiredStatus: "RUNNING", Env: map[string]string{"TOKEN": "literal"}},
+	}
+
+	ts := &rotationTestServer{}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/secrets"):
+			secrets(w, r)
+
+		case r.URL.Path == "/templates":
+			json.NewEncoder(w).Encode([]runpod.Template{
+				{ID: "tpl-1", Env: map[string]string{"TOKEN": runpod.SecretRef("api_token"), "MODE": "dev"}},
+				{ID: "tpl-2", Env: map[string]string{"OTHER": "value"}},
+			})
+
+		case r.URL.Path == "/pods" && r.Method == "POST":
+			var req runpod.CreatePodRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			ts.created = append(ts.created, req)
+			json.NewEncoder(w).Encode(runpod.Pod{ID: "new-" + req.Name, DesiredStatus: "RUNNING"})
+
+		case r.URL.Path == "/pods":
+			json.NewEncoder(w).Encode(map[string]interface{}{"pods": pods})
+
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/restart"):
+			podID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/pods/"), "/restart")
+			restarts[podID]++
+			w.WriteHeader(http.StatusOK)
+
+		case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/pods/"):
+			ts.terminated = append(ts.terminated, strings.TrimPrefix(r.URL.Path, "/pods/"))
+			w.WriteHeader(http.StatusOK)
+
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/pods/"):
+			podID := strings.TrimPrefix(r.URL.Path, "/pods/")
+			status := runpod.PodStatusRunning
+			if failOnce[podID] && restarts[podID] == 1 {
+				status = runpod.PodStatusExited
+			}
+			lastStarted := &runpod.JSONTime{Time: started.Add(time.Duration(restarts[podID]) * time.Minute)}
+			json.NewEncoder(w).Encode(runpod.Pod{ID: podID, DesiredStatus: status, LastStartedAt: lastStarted})
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return ts
+}
+
+func TestRotateSecret(t *testing.T) {
+	store := newMockSecretStore(map[string]string{"api_token": "old"})
+	restarts := make(map[string]int)
+	server := createRotationTestServer(store, nil, restarts)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+
+	report, err := client.RotateSecret(context.Background(), "api_token", "new", &runpod.SecretRotationOptions{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("RotateSecret() error = %v", err)
+	}
+
+	if value, _ := store.get("api_token"); value != "new" {
+		t.Errorf("api_token = %q, want new", value)
+	}
+
+	if strings.Join(report.TemplateIDs, ",") != "tpl-1" {
+		t.Errorf("RotateSecret() templates = %v, want [tpl-1]", report.TemplateIDs)
+	}
+
+	if len(report.Rollouts) != 2 || restarts["pod-env"] != 1 || restarts["pod-template"] != 1 || restarts["pod-other"] != 0 {
+		t.Errorf("RotateSecret() rollouts = %+v, restarts = %v", report.Rollouts, restarts)
+	}
+}
+
+func TestRotateSecretRollback(t *testing.T) {
+	store := newMockSecretStore(map[string]string{"api_token": "old"})
+	restarts := make(map[string]int)
+	server := createRotationTestServer(store, map[string]bool{"pod-template": true}, restarts)
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+
+	report, err := client.RotateSecret(context.Background(), "api_token", "new", &runpod.SecretRotationOptions{
+		PreviousValue: "old",
+	})
+	if err == nil {
+		t.Fatal("RotateSecret() expected error but got none")
+	}
+
+	if !report.RolledBack {
+		t.Errorf("RotateSecret() should have rolled back: %+v", report)
+	}
+
+	if value, _ := store.get("api_token"); value != "old" {
+		t.Errorf("api_token = %q, want old after rollback", value)
+	}
+
+	// Each rolled pod is restarted once for the rollout and once for the rollback
+	if restarts["pod-env"] != 2 || restarts["pod-template"] != 2 {
+		t.Errorf("restarts = %v, want 2 each for pod-env and pod-template", restarts)
+	}
+}
+
+func TestRotateSecretRestartWaitsForNewStart(t *testing.T) {
+	store := newMockSecretStore(map[string]string{"api_token": "old"})
+	secrets := secretStoreHandler(store)
+	started := &runpod.JSONTime{Time: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	// The pod stays RUNNING with the same start time, as if the restart never happened
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		pod := runpod.Pod{ID: "pod-env", DesiredStatus: "RUNNING", LastStartedAt: started,
+			Env: map[string]string{"TOKEN": runpod.SecretRef("api_token")}}
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/secrets"):
+			secrets(w, r)
+		case r.URL.Path == "/templates":
+			json.NewEncoder(w).Encode([]runpod.Template{})
+		case r.URL.Path == "/pods":
+			json.NewEncoder(w).Encode(map[string]interface{}{"pods": []runpod.Pod{pod}})
+		case strings.HasSuffix(r.URL.Path, "/restart"):
+			w.WriteHeader(http.StatusOK)
+		default:
+			json.NewEncoder(w).Encode(pod)
+		}
+	}))
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.RotateSecret(ctx, "api_token", "new", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("RotateSecret() error = %v, want it to keep waiting for the restart", err)
+	}
+}
+
+func TestRotateSecretRecreateKeepsSecretRef(t *testing.T) {
+	store := newMockSecretStore(map[string]string{"api_token": "old"})
+	server := createRotationTestServer(store, nil, make(map[string]int))
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+
+	report, err := client.RotateSecret(context.Background(), "api_token", "new", &runpod.SecretRotationOptions{
+		Strategy: runpod.RolloutRecreate,
+	})
+	if err != nil {
+		t.Fatalf("RotateSecret() error = %v", err)
+	}
+
+	var created *runpod.CreatePodRequest
+	for i := range server.created {
+		if server.created[i].TemplateID == "tpl-1" {
+			created = &server.created[i]
+		}
+	}
+	if created == nil {
+		t.Fatalf("no replacement was created for pod-template: %+v", report)
+	}
+	if created.Env["TOKEN"] != runpod.SecretRef("api_token") {
+		t.Errorf("replacement TOKEN = %q, want the secret reference", created.Env["TOKEN"])
+	}
+	if created.Env["MODE"] != "prod" {
+		t.Errorf("replacement MODE = %q, want the pod's own value", created.Env["MODE"])
+	}
+	if len(report.Rollouts) != 2 || len(server.terminated) != 2 {
+		t.Errorf("rollouts = %+v, terminated = %v", report.Rollouts, server.terminated)
+	}
+}
+
+func TestRotateSecretRecreateRefusesSpotPods(t *testing.T) {
+	store := newMockSecretStore(map[string]string{"api_token": "old"})
+	secrets := secretStoreHandler(store)
+	var created int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/secrets"):
+			secrets(w, r)
+		case r.URL.Path == "/templates":
+			json.NewEncoder(w).Encode([]runpod.Template{})
+		case r.URL.Path == "/pods" && r.Method == "POST":
+			created++
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Path == "/pods":
+			json.NewEncoder(w).Encode(map[string]interface{}{"pods": []runpod.Pod{
+				{ID: "pod-spot", DesiredStatus: "RUNNING", Interruptible: true, Env: map[string]string{"TOKEN": runpod.SecretRef("api_token")}},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := runpod.NewClient("test_key", runpod.WithBaseURL(server.URL))
+
+	_, err := client.RotateSecret(context.Background(), "api_token", "new", &runpod.SecretRotationOptions{Strategy: runpod.RolloutRecreate})
+	if !runpod.IsValidationError(err) {
+		t.Fatalf("RotateSecret() error = %v, want a validation error", err)
+	}
+	if value, _ := store.get("api_token"); value != "old" || created != 0 {
+		t.Errorf("api_token = %q, creates = %d, want nothing changed", value, created)
+	}
+}
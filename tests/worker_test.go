@@ -0,0 +1,254 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+	"github.com/cozy-creator/runpod-go-library/worker"
+)
+
+// ================================
+// WORKER RUNTIME TESTS
+// ================================
+
+func TestWorkerRunLocal(t *testing.T) {
+	handler := func(ctx context.Context, job *runpod.Job) (interface{}, error) {
+		input := job.Input.(map[string]interface{})
+
+		switch input["mode"] {
+		case "fail":
+			return nil, errors.New("bad input")
+		case "panic":
+			panic("boom")
+		case "stream":
+			for i := 0; i < 3; i++ {
+				if err := worker.Yield(ctx, fmt.Sprintf("token-%d", i)); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if err := worker.Progress(ctx, "halfway"); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"echo": input["mode"]}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	jobs, err := worker.RunLocal(ctx, handler,
+		map[string]interface{}{"mode": "echo"},
+		map[string]interface{}{"mode": "stream"},
+		map[string]interface{}{"mode": "fail"},
+		map[string]interface{}{"mode": "panic"},
+	)
+	if err != nil {
+		t.Fatalf("RunLocal() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		job        *runpod.Job
+		wantStatus runpod.JobStatus
+		wantError  string
+		wantChunks int
+	}{
+		{name: "echo", job: jobs[0], wantStatus: runpod.JobStatusCompleted},
+		{name: "stream", job: jobs[1], wantStatus: runpod.JobStatusCompleted, wantChunks: 3},
+		{name: "fail", job: jobs[2], wantStatus: runpod.JobStatusFailed, wantError: "bad input"},
+		{name: "panic", job: jobs[3], wantStatus: runpod.JobStatusFailed, wantError: "handler panicked: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if runpod.JobStatus(tt.job.Status) != tt.wantStatus {
+				t.Errorf("job status = %v, want %v", tt.job.Status, tt.wantStatus)
+			}
+			if tt.job.Error != tt.wantError {
+				t.Errorf("job error = %q, want %q", tt.job.Error, tt.wantError)
+			}
+
+			chunks, _ := tt.job.Stream.([]interface{})
+			if len(chunks) != tt.wantChunks {
+				t.Errorf("job stream = %v, want %d chunks", tt.job.Stream, tt.wantChunks)
+			}
+
+			if tt.wantStatus == runpod.JobStatusCompleted {
+				output, _ := tt.job.Output.(map[string]interface{})
+				if output["echo"] != tt.name {
+					t.Errorf("job output = %v, want echo %s", tt.job.Output, tt.name)
+				}
+			}
+		})
+	}
+}
+
+func TestWorkerConcurrency(t *testing.T) {
+	server := worker.NewTestServer()
+	defer server.Close()
+
+	for i := 0; i < 8; i++ {
+		server.AddJob(map[string]interface{}{"n": i})
+	}
+
+	var running, maxRunning int32
+	handler := func(ctx context.Context, job *runpod.Job) (interface{}, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			observed := atomic.LoadInt32(&maxRunning)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+				break
+			}
+		}
+
+		time.Sleep(30 * time.Millisecond)
+		return "ok", nil
+	}
+
+	cfg := server.Config()
+	cfg.Concurrency = 3
+	w, err := worker.New(handler, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go w.Run(ctx)
+	if err := server.Wait(ctx); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	if got := atomic.LoadInt32(&maxRunning); got != 3 {
+		t.Errorf("max concurrent jobs = %d, want 3", got)
+	}
+}
+
+func TestWorkerGracefulShutdown(t *testing.T) {
+	server := worker.NewTestServer(map[string]interface{}{"slow": true})
+	defer server.Close()
+
+	started := make(chan struct{})
+	handler := func(ctx context.Context, job *runpod.Job) (interface{}, error) {
+		close(started)
+		select {
+		case <-time.After(100 * time.Millisecond):
+			return "finished", nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	w, err := worker.New(handler, server.Config())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	<-started
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	jobs := server.Jobs()
+	if jobs[0].Status != string(runpod.JobStatusCompleted) || jobs[0].Output != "finished" {
+		t.Errorf("in-flight job = %+v, want it to complete during shutdown", jobs[0])
+	}
+}
+
+func TestWorkerWebhookURLs(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	done := make(chan struct{})
+
+	// The URL templates follow RunPod's RUNPOD_WEBHOOK_* variables
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path+"?gpu="+r.URL.Query().Get("gpu"))
+
+		switch {
+		case strings.Contains(r.URL.Path, "/job-take/") && len(requests) == 1:
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "job-42", "input": "hi"})
+		case strings.Contains(r.URL.Path, "/job-take/"):
+			w.WriteHeader(http.StatusNoContent)
+		case strings.Contains(r.URL.Path, "/job-done/") && r.URL.Query().Get("isStream") == "false":
+			close(done)
+		}
+	}))
+	defer server.Close()
+
+	handler := func(ctx context.Context, job *runpod.Job) (interface{}, error) {
+		return "ok", worker.Yield(ctx, "chunk")
+	}
+	w, err := worker.New(handler, worker.Config{
+		JobTakeURL:   server.URL + "/v2/endpoint-1/job-take/$ID?gpu=A40",
+		JobDoneURL:   server.URL + "/v2/endpoint-1/job-done/$RUNPOD_POD_ID/$ID?gpu=A40",
+		JobStreamURL: server.URL + "/v2/endpoint-1/job-stream/$RUNPOD_POD_ID/$ID?gpu=A40",
+		WorkerID:     "pod-7",
+		PollInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go w.Run(ctx)
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatal("worker never posted the job result")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{
+		"GET /v2/endpoint-1/job-take/pod-7?gpu=A40",
+		"POST /v2/endpoint-1/job-stream/pod-7/job-42?gpu=A40",
+		"POST /v2/endpoint-1/job-done/pod-7/job-42?gpu=A40",
+	}
+	if strings.Join(requests[:3], "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %v, want %v", requests[:3], want)
+	}
+}
+
+func TestWorkerHelpersOutsideJob(t *testing.T) {
+	if err := worker.Yield(context.Background(), "chunk"); !errors.Is(err, worker.ErrNoJob) {
+		t.Errorf("Yield() error = %v, want ErrNoJob", err)
+	}
+	if err := worker.Progress(context.Background(), 50); !errors.Is(err, worker.ErrNoJob) {
+		t.Errorf("Progress() error = %v, want ErrNoJob", err)
+	}
+}
+
+func TestWorkerConfigValidation(t *testing.T) {
+	handler := func(ctx context.Context, job *runpod.Job) (interface{}, error) { return nil, nil }
+
+	if _, err := worker.New(handler, worker.Config{}); !runpod.IsValidationError(err) {
+		t.Errorf("New() error = %v, want validation error", err)
+	}
+	if _, err := worker.New(nil, worker.Config{JobTakeURL: "x", JobDoneURL: "y"}); !runpod.IsValidationError(err) {
+		t.Errorf("New() error = %v, want validation error", err)
+	}
+}
@@ -63,7 +63,7 @@ func (jt JSONTime) MarshalJSON() ([]byte, error) {
 type Pod struct {
 	ID                string            `json:"id"`
 	Name              string            `json:"name"`
-	DesiredStatus     string            `json:"desiredStatus"`
+	DesiredStatus     PodStatus         `json:"desiredStatus"`
 	ImageName         string            `json:"image"`
 	GPUCount          int               `json:"gpuCount"`
 	VCPUCount         int               `json:"vcpuCount"`
@@ -81,10 +81,45 @@ type Pod struct {
 	Locked            bool              `json:"locked"`
 	Interruptible     bool              `json:"interruptible"`
 	PublicIP          string            `json:"publicIp,omitempty"`
+	TemplateID        string            `json:"templateId,omitempty"`
+	GPU               *PodGPU           `json:"gpu,omitempty"`
+	Machine           *PodMachine       `json:"machine,omitempty"`
 }
 
+// Status returns the pod's desired status as a string
 func (p *Pod) Status() string {
-	return p.DesiredStatus
+	return string(p.DesiredStatus)
+}
+
+// GPUTypeID returns the GPU type the pod is running on, if known
+func (p *Pod) GPUTypeID() string {
+	if p.GPU != nil && p.GPU.ID != "" {
+		return p.GPU.ID
+	}
+	if p.Machine != nil {
+		return p.Machine.GPUTypeID
+	}
+	return ""
+}
+
+// DataCenterID returns the datacenter hosting the pod, if known
+func (p *Pod) DataCenterID() string {
+	if p.Machine != nil {
+		return p.Machine.DataCenterID
+	}
+	return ""
+}
+
+type PodGPU struct {
+	ID          string `json:"id"`
+	Count       int    `json:"count"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+type PodMachine struct {
+	DataCenterID string `json:"dataCenterId,omitempty"`
+	GPUTypeID    string `json:"gpuTypeId,omitempty"`
+	Location     string `json:"location,omitempty"`
 }
 
 type PodRuntime struct {
@@ -109,6 +144,7 @@ type CreatePodRequest struct {
 	NetworkVolumeID   string            `json:"networkVolumeId,omitempty"`
 	CloudType         string            `json:"cloudType,omitempty"`     // "SECURE" or "COMMUNITY"
 	Interruptible     bool              `json:"interruptible,omitempty"` // For spot instances
+	BidPerGPU         float64           `json:"bidPerGpu,omitempty"`     // Spot bid in $/hr per GPU
 	SupportPublicIP   bool              `json:"supportPublicIp,omitempty"`
 	TemplateID        string            `json:"templateId,omitempty"`
 
@@ -120,9 +156,33 @@ type CreatePodRequest struct {
 	DataCenterPriority string   `json:"dataCenterPriority,omitempty"`
 }
 
+// UpdatePodRequest changes the configuration of an existing pod. Zero-valued
+// fields are left unchanged, except that a non-nil empty Env clears all variables.
 type UpdatePodRequest struct {
-	Name string            `json:"name,omitempty"`
-	Env  map[string]string `json:"env,omitempty"`
+	Name              string            `json:"name,omitempty"`
+	ImageName         string            `json:"imageName,omitempty"`
+	Env               map[string]string `json:"env,omitempty"`
+	Ports             []string          `json:"ports,omitempty"`
+	ContainerDiskInGB int               `json:"containerDiskInGb,omitempty"`
+	VolumeInGB        int               `json:"volumeInGb,omitempty"`
+	VolumeMountPath   string            `json:"volumeMountPath,omitempty"`
+	DockerEntrypoint  []string          `json:"dockerEntrypoint,omitempty"`
+	DockerStartCmd    []string          `json:"dockerStartCmd,omitempty"`
+}
+
+// MarshalJSON keeps a non-nil empty Env in the payload so it can clear variables
+func (r UpdatePodRequest) MarshalJSON() ([]byte, error) {
+	type updatePodRequest UpdatePodRequest
+
+	var env *map[string]string
+	if r.Env != nil {
+		env = &r.Env
+	}
+
+	return json.Marshal(struct {
+		updatePodRequest
+		Env *map[string]string `json:"env,omitempty"`
+	}{updatePodRequest(r), env})
 }
 
 type Endpoint struct {
@@ -153,6 +213,9 @@ type CreateEndpointRequest struct {
 	ExecutionTimeout int      `json:"executionTimeoutMs"`
 }
 
+// UpdateEndpointRequest holds the endpoint settings to change. Zero-valued
+// fields are left unchanged unless SetWorkers is set, which always sends
+// WorkersMin and WorkersMax so an endpoint can be scaled to zero.
 type UpdateEndpointRequest struct {
 	Name             string   `json:"name,omitempty"`
 	GPUTypeIDs       []string `json:"gpuTypeIds,omitempty"`
@@ -162,6 +225,26 @@ type UpdateEndpointRequest struct {
 	WorkersMax       int      `json:"workersMax,omitempty"`
 	IdleTimeout      int      `json:"idleTimeout,omitempty"`
 	ExecutionTimeout int      `json:"executionTimeoutMs,omitempty"`
+	SetWorkers       bool     `json:"-"`
+}
+
+// MarshalJSON sends zero WorkersMin and WorkersMax when SetWorkers is set
+func (r UpdateEndpointRequest) MarshalJSON() ([]byte, error) {
+	type updateEndpointRequest UpdateEndpointRequest
+
+	var workersMin, workersMax *int
+	if r.SetWorkers || r.WorkersMin != 0 {
+		workersMin = &r.WorkersMin
+	}
+	if r.SetWorkers || r.WorkersMax != 0 {
+		workersMax = &r.WorkersMax
+	}
+
+	return json.Marshal(struct {
+		updateEndpointRequest
+		WorkersMin *int `json:"workersMin,omitempty"`
+		WorkersMax *int `json:"workersMax,omitempty"`
+	}{updateEndpointRequest(r), workersMin, workersMax})
 }
 
 type Job struct {
@@ -179,6 +262,15 @@ type Job struct {
 	EndpointID    string      `json:"endpointId,omitempty"`
 }
 
+// RawJob is a Job whose output and stream are left as raw JSON, so large
+// outputs such as base64 images are decoded once, straight into the caller's
+// type, or passed on without decoding at all
+type RawJob struct {
+	Job
+	Output json.RawMessage `json:"output,omitempty"`
+	Stream json.RawMessage `json:"stream,omitempty"`
+}
+
 type RunJobRequest struct {
 	Input interface{} `json:"input"`
 }
@@ -248,12 +340,23 @@ type GPUType struct {
 	CommunityCloud bool    `json:"communityCloud"`
 	SecureCloud    bool    `json:"secureCloud"`
 	LowestPrice    *Price  `json:"lowestPrice,omitempty"`
+
+	// Per-cloud prices, only populated by GetGPUAvailability
+	SecurePrice        float64 `json:"securePrice,omitempty"`
+	CommunityPrice     float64 `json:"communityPrice,omitempty"`
+	SecureSpotPrice    float64 `json:"secureSpotPrice,omitempty"`
+	CommunitySpotPrice float64 `json:"communitySpotPrice,omitempty"`
 }
 
 type Price struct {
 	MinimumBidPrice      float64 `json:"minimumBidPrice"`
 	UninterruptablePrice float64 `json:"uninterruptablePrice"`
 	InterruptablePrice   float64 `json:"interruptablePrice,omitempty"`
+
+	// Stock details, only populated by GetGPUAvailability
+	StockStatus           string `json:"stockStatus,omitempty"` // "High", "Medium" or "Low"; empty when none are available
+	MaxUnreservedGPUCount int    `json:"maxUnreservedGpuCount,omitempty"`
+	AvailableGPUCounts    []int  `json:"availableGpuCounts,omitempty"`
 }
 
 type Datacenter struct {
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// Emitter delivers streamed output and progress updates for a running job.
+// The Worker sends them to RunPod; other runtimes such as a local emulator
+// can provide their own implementation.
+type Emitter interface {
+	Stream(ctx context.Context, jobID string, chunk interface{}) error
+	Progress(ctx context.Context, jobID string, progress interface{}) error
+}
+
+// ErrNoJob is returned by Yield and Progress when ctx does not belong to a job
+var ErrNoJob = errors.New("worker: context does not belong to a job")
+
+type jobContextKey struct{}
+
+type jobContext struct {
+	job     *runpod.Job
+	emitter Emitter
+}
+
+// NewJobContext returns a context for running handler on job, routing Yield
+// and Progress calls to emitter
+func NewJobContext(ctx context.Context, job *runpod.Job, emitter Emitter) context.Context {
+	return context.WithValue(ctx, jobContextKey{}, &jobContext{job: job, emitter: emitter})
+}
+
+// JobFromContext returns the job a handler context belongs to
+func JobFromContext(ctx context.Context) (*runpod.Job, bool) {
+	jc, ok := ctx.Value(jobContextKey{}).(*jobContext)
+	if !ok {
+		return nil, false
+	}
+	return jc.job, true
+}
+
+// Yield streams a partial result for the current job. Clients receive it
+// through StreamResults while the handler keeps running.
+func Yield(ctx context.Context, chunk interface{}) error {
+	jc, ok := ctx.Value(jobContextKey{}).(*jobContext)
+	if !ok || jc.emitter == nil {
+		return ErrNoJob
+	}
+	return jc.emitter.Stream(ctx, jc.job.ID, chunk)
+}
+
+// Progress reports a progress update for the current job. Clients see it as
+// the job's output while its status is IN_PROGRESS.
+func Progress(ctx context.Context, progress interface{}) error {
+	jc, ok := ctx.Value(jobContextKey{}).(*jobContext)
+	if !ok || jc.emitter == nil {
+		return ErrNoJob
+	}
+	return jc.emitter.Progress(ctx, jc.job.ID, progress)
+}
@@ -0,0 +1,252 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+// testWorkerID is the worker ID in the test server's configuration
+const testWorkerID = "local-worker"
+
+// TestServer is an in-memory stand-in for RunPod's job webhooks. It queues
+// jobs, hands them to workers and records their streamed chunks, progress and
+// final results, so handlers can be exercised locally.
+type TestServer struct {
+	server *httptest.Server
+
+	mu      sync.Mutex
+	nextID  int
+	pending []*runpod.Job
+	jobs    map[string]*runpod.Job
+	order   []string
+	updated chan struct{}
+}
+
+// NewTestServer starts a test server with a job queued for each input
+func NewTestServer(inputs ...interface{}) *TestServer {
+	s := &TestServer{
+		jobs:    make(map[string]*runpod.Job),
+		updated: make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /job-take/{worker}", s.handleTake)
+	mux.HandleFunc("POST /job-done/{worker}/{job}", s.handleResult)
+	mux.HandleFunc("POST /job-stream/{worker}/{job}", s.handleResult)
+	s.server = httptest.NewServer(mux)
+
+	for _, input := range inputs {
+		s.AddJob(input)
+	}
+
+	return s
+}
+
+// Config returns a worker configuration pointing at the test server
+func (s *TestServer) Config() Config {
+	return Config{
+		JobTakeURL:   s.server.URL + "/job-take/$ID",
+		JobDoneURL:   s.server.URL + "/job-done/$RUNPOD_POD_ID/$ID",
+		JobStreamURL: s.server.URL + "/job-stream/$RUNPOD_POD_ID/$ID",
+		WorkerID:     testWorkerID,
+		PollInterval: 10 * time.Millisecond,
+	}
+}
+
+// URL returns the base URL of the test server
+func (s *TestServer) URL() string {
+	return s.server.URL
+}
+
+// AddJob queues a job and returns its ID
+func (s *TestServer) AddJob(input interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	job := &runpod.Job{
+		ID:        fmt.Sprintf("local-job-%d", s.nextID),
+		Status:    string(runpod.JobStatusInQueue),
+		Input:     input,
+		CreatedAt: &runpod.JSONTime{Time: time.Now()},
+	}
+
+	s.jobs[job.ID] = job
+	s.order = append(s.order, job.ID)
+	s.pending = append(s.pending, job)
+	s.notifyLocked()
+
+	return job.ID
+}
+
+// Job returns a snapshot of the job with the given ID
+func (s *TestServer) Job(id string) (*runpod.Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	snapshot := *job
+	return &snapshot, true
+}
+
+// Jobs returns snapshots of all jobs in the order they were queued
+func (s *TestServer) Jobs() []*runpod.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]*runpod.Job, 0, len(s.order))
+	for _, id := range s.order {
+		snapshot := *s.jobs[id]
+		jobs = append(jobs, &snapshot)
+	}
+	return jobs
+}
+
+// Wait blocks until every queued job has completed or failed
+func (s *TestServer) Wait(ctx context.Context) error {
+	for {
+		s.mu.Lock()
+		finished := true
+		for _, job := range s.jobs {
+			if !isFinished(job.Status) {
+				finished = false
+				break
+			}
+		}
+		updated := s.updated
+		s.mu.Unlock()
+
+		if finished {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-updated:
+		}
+	}
+}
+
+// Close shuts the test server down
+func (s *TestServer) Close() {
+	s.server.Close()
+}
+
+// handleTake hands the next queued job to a worker
+func (s *TestServer) handleTake(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("worker") != testWorkerID {
+		http.Error(w, "unknown worker", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.pending) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	job := s.pending[0]
+	s.pending = s.pending[1:]
+	job.Status = string(runpod.JobStatusInProgress)
+	job.StartedAt = &runpod.JSONTime{Time: time.Now()}
+	s.notifyLocked()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"id": job.ID, "input": job.Input})
+}
+
+// handleResult records a streamed chunk, progress update or final result
+func (s *TestServer) handleResult(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("worker") != testWorkerID {
+		http.Error(w, "unknown worker", http.StatusBadRequest)
+		return
+	}
+
+	var payload struct {
+		Status string      `json:"status"`
+		Output interface{} `json:"output"`
+		Error  string      `json:"error"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[r.PathValue("job")]
+	if !ok {
+		http.Error(w, "unknown job", http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case r.URL.Query().Get("isStream") == "true":
+		chunks, _ := job.Stream.([]interface{})
+		job.Stream = append(chunks, payload.Output)
+	case payload.Status == string(runpod.JobStatusInProgress):
+		job.Output = payload.Output
+	case payload.Error != "":
+		job.Status = string(runpod.JobStatusFailed)
+		job.Error = payload.Error
+		job.CompletedAt = &runpod.JSONTime{Time: time.Now()}
+	default:
+		job.Status = string(runpod.JobStatusCompleted)
+		job.Output = payload.Output
+		job.CompletedAt = &runpod.JSONTime{Time: time.Now()}
+	}
+	s.notifyLocked()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// notifyLocked wakes up Wait callers. s.mu must be held.
+func (s *TestServer) notifyLocked() {
+	close(s.updated)
+	s.updated = make(chan struct{})
+}
+
+// isFinished reports whether a job status is final for the test server
+func isFinished(status string) bool {
+	return status == string(runpod.JobStatusCompleted) || status == string(runpod.JobStatusFailed)
+}
+
+// RunLocal runs handler against a TestServer with a job for each input and
+// returns the finished jobs in input order
+func RunLocal(ctx context.Context, handler Handler, inputs ...interface{}) ([]*runpod.Job, error) {
+	server := NewTestServer(inputs...)
+	defer server.Close()
+
+	w, err := New(handler, server.Config())
+	if err != nil {
+		return nil, err
+	}
+
+	runCtx, stop := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Run(runCtx)
+	}()
+
+	waitErr := server.Wait(ctx)
+	stop()
+	if err := <-done; err != nil {
+		return nil, err
+	}
+
+	return server.Jobs(), waitErr
+}
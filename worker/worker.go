@@ -0,0 +1,360 @@
+// Package worker runs Go handlers as RunPod serverless workers.
+//
+// A worker takes jobs from RunPod's job-take webhook, runs the registered
+// handler and posts the result to the job-done webhook:
+//
+//	func main() {
+//		err := worker.Start(func(ctx context.Context, job *runpod.Job) (interface{}, error) {
+//			worker.Progress(ctx, "loading model")
+//			return map[string]interface{}{"echo": job.Input}, nil
+//		})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-library"
+)
+
+const (
+	// DefaultPollInterval is how long a worker waits after finding no job
+	DefaultPollInterval = 1 * time.Second
+
+	// DefaultShutdownTimeout is how long in-flight jobs may run after shutdown starts
+	DefaultShutdownTimeout = 30 * time.Second
+)
+
+// Handler processes a single job and returns its output.
+// Returning an error marks the job as FAILED with the error's message.
+type Handler func(ctx context.Context, job *runpod.Job) (interface{}, error)
+
+// Config describes how a worker reaches RunPod's job webhooks
+type Config struct {
+	// JobTakeURL is the job-take webhook (RUNPOD_WEBHOOK_GET_JOB)
+	JobTakeURL string
+
+	// JobDoneURL is the job-done webhook (RUNPOD_WEBHOOK_POST_OUTPUT)
+	JobDoneURL string
+
+	// JobStreamURL is the stream webhook (RUNPOD_WEBHOOK_POST_STREAM).
+	// Defaults to JobDoneURL.
+	JobStreamURL string
+
+	// APIKey authenticates the worker (RUNPOD_AI_API_KEY)
+	APIKey string
+
+	// WorkerID replaces $ID in the job-take URL and $RUNPOD_POD_ID in the
+	// job-done and stream URLs (RUNPOD_POD_ID)
+	WorkerID string
+
+	// Concurrency is how many jobs run at once. Defaults to 1.
+	Concurrency int
+
+	// PollInterval is how long to wait after finding no job. Defaults to 1 second.
+	PollInterval time.Duration
+
+	// ShutdownTimeout bounds how long in-flight jobs may keep running after
+	// Run's context is cancelled. Defaults to 30 seconds.
+	ShutdownTimeout time.Duration
+
+	// HTTPClient is used for webhook requests. Defaults to a client without timeout,
+	// since job-take requests long-poll.
+	HTTPClient *http.Client
+
+	// Logger receives worker diagnostics. Defaults to the standard logger.
+	Logger runpod.Logger
+}
+
+// ConfigFromEnv reads the worker configuration RunPod injects into serverless containers
+func ConfigFromEnv() Config {
+	cfg := Config{
+		JobTakeURL:   os.Getenv("RUNPOD_WEBHOOK_GET_JOB"),
+		JobDoneURL:   os.Getenv("RUNPOD_WEBHOOK_POST_OUTPUT"),
+		JobStreamURL: os.Getenv("RUNPOD_WEBHOOK_POST_STREAM"),
+		APIKey:       os.Getenv("RUNPOD_AI_API_KEY"),
+		WorkerID:     os.Getenv("RUNPOD_POD_ID"),
+	}
+
+	if concurrency, err := strconv.Atoi(os.Getenv("RUNPOD_WORKER_CONCURRENCY")); err == nil {
+		cfg.Concurrency = concurrency
+	}
+
+	return cfg
+}
+
+// Worker takes jobs from RunPod and runs them through a Handler
+type Worker struct {
+	handler Handler
+	cfg     Config
+}
+
+// New creates a worker for handler
+func New(handler Handler, cfg Config) (*Worker, error) {
+	if handler == nil {
+		return nil, runpod.NewValidationError("handler", "is required")
+	}
+	if cfg.JobTakeURL == "" {
+		return nil, runpod.NewValidationError("JobTakeURL", "is required")
+	}
+	if cfg.JobDoneURL == "" {
+		return nil, runpod.NewValidationError("JobDoneURL", "is required")
+	}
+
+	if cfg.JobStreamURL == "" {
+		cfg.JobStreamURL = cfg.JobDoneURL
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		cfg.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{}
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.Default()
+	}
+
+	return &Worker{handler: handler, cfg: cfg}, nil
+}
+
+// Start runs handler with the configuration from the environment until the
+// process receives SIGINT or SIGTERM
+func Start(handler Handler) error {
+	w, err := New(handler, ConfigFromEnv())
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return w.Run(ctx)
+}
+
+// Run takes and processes jobs until ctx is cancelled. In-flight jobs are then
+// given up to ShutdownTimeout to finish before their contexts are cancelled.
+func (w *Worker) Run(ctx context.Context) error {
+	// Jobs outlive ctx so they can finish during a graceful shutdown
+	jobCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx, jobCtx)
+		}()
+	}
+
+	<-ctx.Done()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(w.cfg.ShutdownTimeout):
+		w.cfg.Logger.Printf("[WORKER] Shutdown timeout reached, cancelling in-flight jobs")
+		cancelJobs()
+		<-done
+	}
+
+	return nil
+}
+
+// loop repeatedly takes a job and runs it until ctx is cancelled
+func (w *Worker) loop(ctx, jobCtx context.Context) {
+	for ctx.Err() == nil {
+		job, err := w.takeJob(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.cfg.Logger.Printf("[WORKER] Failed to take job: %v", err)
+			}
+		}
+
+		if job == nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(w.cfg.PollInterval):
+			}
+			continue
+		}
+
+		w.runJob(jobCtx, job)
+	}
+}
+
+// takeJob asks the job-take webhook for the next job, returning nil if there is none
+func (w *Worker) takeJob(ctx context.Context) (*runpod.Job, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", w.webhookURL(w.cfg.JobTakeURL, "", nil), nil)
+	if err != nil {
+		return nil, err
+	}
+	w.setHeaders(req)
+
+	resp, err := w.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, runpod.NewNetworkError("job take request failed", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, runpod.NewNetworkError("failed to read job", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, runpod.NewAPIError(resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+
+	var job runpod.Job
+	if err := json.Unmarshal(body, &job); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job: %w", err)
+	}
+	if job.ID == "" {
+		return nil, nil
+	}
+
+	return &job, nil
+}
+
+// runJob runs the handler on job and reports the result
+func (w *Worker) runJob(ctx context.Context, job *runpod.Job) {
+	job.Status = string(runpod.JobStatusInProgress)
+
+	output, err := w.callHandler(NewJobContext(ctx, job, w), job)
+
+	result := map[string]interface{}{}
+	if err != nil {
+		result["error"] = err.Error()
+	} else {
+		result["output"] = output
+	}
+
+	// Deliver the result even if in-flight jobs were cancelled during shutdown
+	if postErr := w.post(context.WithoutCancel(ctx), w.cfg.JobDoneURL, job.ID, false, result); postErr != nil {
+		w.cfg.Logger.Printf("[WORKER] Failed to report result for job %s: %v", job.ID, postErr)
+	}
+}
+
+// callHandler runs the handler, converting a panic into an error
+func (w *Worker) callHandler(ctx context.Context, job *runpod.Job) (output interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+
+	return w.handler(ctx, job)
+}
+
+// Stream implements Emitter by posting a chunk to the stream webhook
+func (w *Worker) Stream(ctx context.Context, jobID string, chunk interface{}) error {
+	return w.post(ctx, w.cfg.JobStreamURL, jobID, true, map[string]interface{}{"output": chunk})
+}
+
+// Progress implements Emitter by posting an IN_PROGRESS update to the job-done webhook
+func (w *Worker) Progress(ctx context.Context, jobID string, progress interface{}) error {
+	return w.post(ctx, w.cfg.JobDoneURL, jobID, false, map[string]interface{}{
+		"status": runpod.JobStatusInProgress,
+		"output": progress,
+	})
+}
+
+// post sends a JSON payload for a job to a webhook
+func (w *Worker) post(ctx context.Context, webhook, jobID string, isStream bool, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job result: %w", err)
+	}
+
+	params := url.Values{"isStream": {strconv.FormatBool(isStream)}}
+	req, err := http.NewRequestWithContext(ctx, "POST", w.webhookURL(webhook, jobID, params), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	w.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return runpod.NewNetworkError("job webhook request failed", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return runpod.NewAPIError(resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
+
+// webhookURL fills in the worker and job IDs of a webhook URL template. The
+// job-take URL uses $ID for the worker; the result URLs use $RUNPOD_POD_ID for
+// the worker and $ID for the job.
+func (w *Worker) webhookURL(template, jobID string, params url.Values) string {
+	raw := template
+	if jobID == "" {
+		raw = strings.ReplaceAll(raw, "$ID", w.cfg.WorkerID)
+	} else {
+		raw = strings.ReplaceAll(raw, "$RUNPOD_POD_ID", w.cfg.WorkerID)
+		raw = strings.ReplaceAll(raw, "$ID", jobID)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || len(params) == 0 {
+		return raw
+	}
+
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// setHeaders sets the worker authentication headers
+func (w *Worker) setHeaders(req *http.Request) {
+	if w.cfg.APIKey != "" {
+		req.Header.Set("Authorization", w.cfg.APIKey)
+	}
+	req.Header.Set("User-Agent", runpod.DefaultUserAgent+" worker")
+}